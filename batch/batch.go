@@ -0,0 +1,359 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package batch generates and submits batch scheduler job scripts for commands
+// built by external CommandBuilders. SLURM and PBS Professional are supported.
+package batch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/biogo/external"
+)
+
+var (
+	ErrNoCommands = errors.New("batch: no commands")
+	ErrNoJobID    = errors.New("batch: no job ID in submission output")
+)
+
+// Scheduler is a batch scheduler.
+type Scheduler int
+
+const (
+	SLURM Scheduler = iota // SLURM, submitting with sbatch.
+	PBS                    // PBS Professional, submitting with qsub.
+)
+
+func (s Scheduler) String() string {
+	switch s {
+	case SLURM:
+		return "SLURM"
+	case PBS:
+		return "PBS"
+	}
+	return fmt.Sprintf("Scheduler(%d)", int(s))
+}
+
+// Redirect is a CommandBuilder that specifies redirection of the standard streams
+// of the command built by the embedded CommandBuilder when it is run in a job script.
+// Empty fields are not redirected.
+type Redirect struct {
+	external.CommandBuilder
+
+	Stdin  string
+	Stdout string
+	Stderr string
+}
+
+// Job specifies the parameters of a batch job.
+type Job struct {
+	// Scheduler is the batch scheduler.
+	Scheduler Scheduler
+
+	// Name is the name of the job.
+	Name string
+
+	// Queue is the queue, or SLURM partition,
+	// to submit the job to.
+	Queue string
+
+	// Account is the account charged for the job.
+	Account string
+
+	// Time is the wall-clock time limit of the job.
+	Time time.Duration
+
+	// CPUs and Memory specify the resources required by
+	// each task of the job. If zero, the largest values
+	// of the hints given by ResourceHinter builders are
+	// used.
+	CPUs   int
+	Memory int64
+
+	// Output and Error are the paths of the files that
+	// receive the standard output and error of the job.
+	Output string
+	Error  string
+
+	// Directives holds additional scheduler directives
+	// that are written verbatim after the directive
+	// prefix, for example "--qos=long" for SLURM.
+	Directives []string
+
+	// Shell is the interpreter for the job script.
+	// If Shell is empty, "/bin/sh" is used.
+	Shell string
+}
+
+// Script returns a job script that runs the commands built by cbs. If more than one
+// CommandBuilder is given, the script describes a job array with one task for each
+// command, indexed from zero in the order of cbs.
+func (j Job) Script(cbs ...external.CommandBuilder) ([]byte, error) {
+	if len(cbs) == 0 {
+		return nil, ErrNoCommands
+	}
+	lines := make([]string, len(cbs))
+	res := external.Resources{CPUs: j.CPUs, Memory: j.Memory}
+	for i, cb := range cbs {
+		var err error
+		lines[i], err = commandLine(cb)
+		if err != nil {
+			return nil, err
+		}
+		if h, ok := unwrap(cb).(external.ResourceHinter); ok {
+			hint := h.Resources()
+			if j.CPUs == 0 && hint.CPUs > res.CPUs {
+				res.CPUs = hint.CPUs
+			}
+			if j.Memory == 0 && hint.Memory > res.Memory {
+				res.Memory = hint.Memory
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	shell := j.Shell
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	fmt.Fprintf(&buf, "#!%s\n", shell)
+	var (
+		prefix string
+		index  string
+	)
+	switch j.Scheduler {
+	case SLURM:
+		prefix = "#SBATCH "
+		index = "$SLURM_ARRAY_TASK_ID"
+		j.slurm(&buf, res, len(cbs))
+	case PBS:
+		prefix = "#PBS "
+		index = "$PBS_ARRAY_INDEX"
+		j.pbs(&buf, res, len(cbs))
+	default:
+		return nil, fmt.Errorf("batch: unknown scheduler: %v", j.Scheduler)
+	}
+	for _, d := range j.Directives {
+		fmt.Fprintf(&buf, "%s%s\n", prefix, d)
+	}
+	buf.WriteByte('\n')
+	if j.Scheduler == PBS {
+		buf.WriteString("cd \"${PBS_O_WORKDIR:-.}\"\n")
+	}
+
+	if len(lines) == 1 {
+		fmt.Fprintf(&buf, "%s\n", lines[0])
+		return buf.Bytes(), nil
+	}
+	fmt.Fprintf(&buf, "case \"%s\" in\n", index)
+	for i, l := range lines {
+		fmt.Fprintf(&buf, "%d)\n\t%s\n\t;;\n", i, l)
+	}
+	fmt.Fprintf(&buf, "*)\n\techo \"unknown task: %s\" >&2\n\texit 1\n\t;;\nesac\n", index)
+	return buf.Bytes(), nil
+}
+
+func (j Job) slurm(buf *bytes.Buffer, res external.Resources, n int) {
+	directive := func(format string, args ...interface{}) {
+		fmt.Fprintf(buf, "#SBATCH "+format+"\n", args...)
+	}
+	if j.Name != "" {
+		directive("--job-name=%s", Quote(j.Name))
+	}
+	if j.Queue != "" {
+		directive("--partition=%s", Quote(j.Queue))
+	}
+	if j.Account != "" {
+		directive("--account=%s", Quote(j.Account))
+	}
+	if j.Time > 0 {
+		directive("--time=%s", days(j.Time))
+	}
+	if res.CPUs > 0 {
+		directive("--cpus-per-task=%d", res.CPUs)
+	}
+	if res.Memory > 0 {
+		directive("--mem=%dM", megabytes(res.Memory))
+	}
+	if j.Output != "" {
+		directive("--output=%s", Quote(j.Output))
+	}
+	if j.Error != "" {
+		directive("--error=%s", Quote(j.Error))
+	}
+	if n > 1 {
+		directive("--array=0-%d", n-1)
+	}
+}
+
+func (j Job) pbs(buf *bytes.Buffer, res external.Resources, n int) {
+	directive := func(format string, args ...interface{}) {
+		fmt.Fprintf(buf, "#PBS "+format+"\n", args...)
+	}
+	if j.Name != "" {
+		directive("-N %s", Quote(j.Name))
+	}
+	if j.Queue != "" {
+		directive("-q %s", Quote(j.Queue))
+	}
+	if j.Account != "" {
+		directive("-A %s", Quote(j.Account))
+	}
+	if j.Time > 0 {
+		directive("-l walltime=%s", hours(j.Time))
+	}
+	if res.CPUs > 0 || res.Memory > 0 {
+		sel := "select=1"
+		if res.CPUs > 0 {
+			sel += fmt.Sprintf(":ncpus=%d", res.CPUs)
+		}
+		if res.Memory > 0 {
+			sel += fmt.Sprintf(":mem=%dmb", megabytes(res.Memory))
+		}
+		directive("-l %s", sel)
+	}
+	if j.Output != "" {
+		directive("-o %s", Quote(j.Output))
+	}
+	if j.Error != "" {
+		directive("-e %s", Quote(j.Error))
+	}
+	if n > 1 {
+		directive("-J 0-%d", n-1)
+	}
+}
+
+// megabytes returns b in mebibytes, rounded up.
+func megabytes(b int64) int64 { return (b + 1<<20 - 1) >> 20 }
+
+// hours returns d formatted as HH:MM:SS, rounded up to the nearest second.
+func hours(d time.Duration) string {
+	s := int64((d + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", s/3600, s/60%60, s%60)
+}
+
+// days returns d formatted as D-HH:MM:SS, rounded up to the nearest second.
+func days(d time.Duration) string {
+	s := int64((d + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%d-%02d:%02d:%02d", s/86400, s/3600%24, s/60%60, s%60)
+}
+
+// unwrap returns the CommandBuilder held by a Redirect, or cb.
+func unwrap(cb external.CommandBuilder) external.CommandBuilder {
+	if r, ok := cb.(Redirect); ok {
+		return unwrap(r.CommandBuilder)
+	}
+	if r, ok := cb.(*Redirect); ok {
+		return unwrap(r.CommandBuilder)
+	}
+	return cb
+}
+
+// commandLine returns the shell command line for the command built by cb.
+func commandLine(cb external.CommandBuilder) (string, error) {
+	cmd, err := cb.BuildCommand()
+	if err != nil {
+		return "", err
+	}
+	args := append([]string(nil), cmd.Args...)
+	if filepath.IsAbs(cmd.Path) {
+		args[0] = cmd.Path
+	}
+	for i, a := range args {
+		args[i] = Quote(a)
+	}
+	cl := strings.Join(args, " ")
+	if cmd.Dir != "" {
+		cl = "cd " + Quote(cmd.Dir) + " && " + cl
+	}
+
+	var r Redirect
+	switch cb := cb.(type) {
+	case Redirect:
+		r = cb
+	case *Redirect:
+		r = *cb
+	}
+	for _, s := range []struct {
+		op, path string
+	}{
+		{"<", r.Stdin},
+		{">", r.Stdout},
+		{"2>", r.Stderr},
+	} {
+		if s.path != "" {
+			cl += " " + s.op + Quote(s.path)
+		}
+	}
+	return cl, nil
+}
+
+var unquoted = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// Quote returns s quoted for use as a single word in a POSIX shell command line.
+func Quote(s string) string {
+	if unquoted.MatchString(s) {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+var slurmJobID = regexp.MustCompile(`Submitted batch job (\d+)`)
+
+// Submit submits the job script to the scheduler using sbatch or qsub, passing the
+// script on standard input, and returns the job ID reported by the scheduler. The
+// submission command is resolved by external.DefaultResolver.
+func (j Job) Submit(script []byte) (id string, err error) {
+	var name string
+	switch j.Scheduler {
+	case SLURM:
+		name = "sbatch"
+	case PBS:
+		name = "qsub"
+	default:
+		return "", fmt.Errorf("batch: unknown scheduler: %v", j.Scheduler)
+	}
+	cmd, err := external.Command([]string{name})
+	if err != nil {
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(script)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("batch: %s failed: %v: %s", name, err, strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+	return parseJobID(j.Scheduler, stdout.String())
+}
+
+// parseJobID returns the job ID in the output of a submission command.
+func parseJobID(s Scheduler, out string) (string, error) {
+	switch s {
+	case SLURM:
+		m := slurmJobID.FindStringSubmatch(out)
+		if m == nil {
+			return "", ErrNoJobID
+		}
+		return m[1], nil
+	case PBS:
+		id := strings.TrimSpace(out)
+		if id == "" || strings.ContainsAny(id, " \t\n") {
+			return "", ErrNoJobID
+		}
+		return id, nil
+	}
+	return "", fmt.Errorf("batch: unknown scheduler: %v", s)
+}
@@ -0,0 +1,252 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package batch
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/biogo/external"
+	"github.com/biogo/external/last"
+	"github.com/biogo/external/mafft"
+
+	"gopkg.in/check.v1"
+)
+
+// Tests
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct {
+	dir string
+}
+
+var _ = check.Suite(&S{})
+
+var tools = []string{"mafft", "lastal", "lastdb", "sbatch", "qsub"}
+
+func (s *S) SetUpSuite(c *check.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("shell scripts not executable")
+	}
+	s.dir = c.MkDir()
+	for _, t := range tools {
+		s.tool(c, t, "")
+	}
+}
+
+func (s *S) TearDownSuite(c *check.C) {
+	for _, t := range tools {
+		external.DefaultResolver.Register(t, "")
+	}
+}
+
+// tool writes a fake tool executing script and registers it with
+// the default resolver.
+func (s *S) tool(c *check.C, name, script string) {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755)
+	c.Assert(err, check.Equals, nil)
+	external.DefaultResolver.Register(name, path)
+}
+
+func (s *S) TestQuote(c *check.C) {
+	for _, t := range []struct{ in, want string }{
+		{"simple", "simple"},
+		{"a/b-c_d.fa", "a/b-c_d.fa"},
+		{"--seed=1,2", "--seed=1,2"},
+		{"", "''"},
+		{"two words", "'two words'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME", "'$HOME'"},
+		{"a;rm -rf /", "'a;rm -rf /'"},
+	} {
+		c.Check(Quote(t.in), check.Equals, t.want)
+	}
+}
+
+func (s *S) TestScriptSLURM(c *check.C) {
+	j := Job{
+		Name:       "align",
+		Queue:      "long",
+		Time:       26*time.Hour + 30*time.Minute,
+		Memory:     1500 << 20,
+		Output:     "align.log",
+		Directives: []string{"--qos=high"},
+	}
+	script, err := j.Script(Redirect{
+		CommandBuilder: mafft.Mafft{Threads: 4, InFile: "in put.fa"},
+		Stdout:         "out.aln",
+	})
+	c.Check(err, check.Equals, nil)
+	c.Check(string(script), check.Equals, strings.Replace(`#!/bin/sh
+#SBATCH --job-name=align
+#SBATCH --partition=long
+#SBATCH --time=1-02:30:00
+#SBATCH --cpus-per-task=4
+#SBATCH --mem=1500M
+#SBATCH --output=align.log
+#SBATCH --qos=high
+
+$TOOLS/mafft --thread 4 'in put.fa' >out.aln
+`, "$TOOLS", s.dir, -1))
+}
+
+func (s *S) TestScriptResources(c *check.C) {
+	for _, t := range []struct {
+		j    Job
+		cb   external.CommandBuilder
+		want string
+	}{
+		{
+			cb: last.Align{Threads: 4, DB: "db", InFiles: []string{"a.fa"}},
+			want: `#!/bin/sh
+#SBATCH --cpus-per-task=4
+
+$TOOLS/lastal -P 4 db a.fa
+`,
+		},
+		{
+			cb: last.DB{Threads: 2, VolumeSize: 2 << 30, OutFile: "db", InFiles: []string{"ref.fa"}},
+			want: `#!/bin/sh
+#SBATCH --cpus-per-task=2
+
+$TOOLS/lastdb -s 2147483648 -P 2 db ref.fa
+`,
+		},
+		{
+			j:  Job{CPUs: 1},
+			cb: last.Align{Threads: 4, DB: "db", InFiles: []string{"a.fa"}},
+			want: `#!/bin/sh
+#SBATCH --cpus-per-task=1
+
+$TOOLS/lastal -P 4 db a.fa
+`,
+		},
+	} {
+		script, err := t.j.Script(t.cb)
+		c.Check(err, check.Equals, nil)
+		c.Check(string(script), check.Equals, strings.Replace(t.want, "$TOOLS", s.dir, -1))
+	}
+}
+
+func (s *S) TestScriptArray(c *check.C) {
+	for _, t := range []struct {
+		j    Job
+		want string
+	}{
+		{
+			j: Job{Scheduler: SLURM, CPUs: 2},
+			want: `#!/bin/sh
+#SBATCH --cpus-per-task=2
+#SBATCH --array=0-2
+
+case "$SLURM_ARRAY_TASK_ID" in
+0)
+	$TOOLS/lastal db a.fa
+	;;
+1)
+	$TOOLS/lastal db b.fa
+	;;
+2)
+	$TOOLS/mafft --thread 8 - <c.fa >c.aln
+	;;
+*)
+	echo "unknown task: $SLURM_ARRAY_TASK_ID" >&2
+	exit 1
+	;;
+esac
+`,
+		},
+		{
+			j: Job{Scheduler: PBS, Name: "many", Time: 90 * time.Minute, Memory: 1 << 30},
+			want: `#!/bin/sh
+#PBS -N many
+#PBS -l walltime=01:30:00
+#PBS -l select=1:ncpus=8:mem=1024mb
+#PBS -J 0-2
+
+cd "${PBS_O_WORKDIR:-.}"
+case "$PBS_ARRAY_INDEX" in
+0)
+	$TOOLS/lastal db a.fa
+	;;
+1)
+	$TOOLS/lastal db b.fa
+	;;
+2)
+	$TOOLS/mafft --thread 8 - <c.fa >c.aln
+	;;
+*)
+	echo "unknown task: $PBS_ARRAY_INDEX" >&2
+	exit 1
+	;;
+esac
+`,
+		},
+	} {
+		script, err := t.j.Script(
+			last.Align{DB: "db", InFiles: []string{"a.fa"}},
+			last.Align{DB: "db", InFiles: []string{"b.fa"}},
+			&Redirect{CommandBuilder: mafft.Mafft{Threads: 8}, Stdin: "c.fa", Stdout: "c.aln"},
+		)
+		c.Check(err, check.Equals, nil)
+		c.Check(string(script), check.Equals, strings.Replace(t.want, "$TOOLS", s.dir, -1))
+	}
+
+	_, err := Job{}.Script()
+	c.Check(err, check.Equals, ErrNoCommands)
+	_, err = Job{}.Script(last.Align{})
+	c.Check(err, check.Equals, last.ErrMissingRequired)
+}
+
+func (s *S) TestSubmit(c *check.C) {
+	script := []byte("#!/bin/sh\ntrue\n")
+	for _, t := range []struct {
+		sched  Scheduler
+		stub   string
+		id     string
+		errMsg string
+	}{
+		{
+			sched: SLURM,
+			stub:  `grep -q '^true$' && echo "Submitted batch job 4242"`,
+			id:    "4242",
+		},
+		{
+			sched: SLURM,
+			stub:  `echo "Submitted batch job 17 on cluster hpc"`,
+			id:    "17",
+		},
+		{
+			sched: PBS,
+			stub:  `cat >/dev/null; echo 1234.pbs-server`,
+			id:    "1234.pbs-server",
+		},
+		{
+			sched:  SLURM,
+			stub:   `echo "sbatch: error: invalid partition" >&2; exit 1`,
+			errMsg: "batch: sbatch failed: exit status 1: sbatch: error: invalid partition",
+		},
+		{
+			sched:  SLURM,
+			stub:   `echo "maintenance"`,
+			errMsg: ErrNoJobID.Error(),
+		},
+	} {
+		name := map[Scheduler]string{SLURM: "sbatch", PBS: "qsub"}[t.sched]
+		s.tool(c, name, t.stub)
+		id, err := Job{Scheduler: t.sched}.Submit(script)
+		if t.errMsg != "" {
+			c.Check(err, check.ErrorMatches, t.errMsg)
+			continue
+		}
+		c.Check(err, check.Equals, nil)
+		c.Check(id, check.Equals, t.id)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// BindFlags registers a flag in fs for each field of the struct pointed to by cb that
+// has an argument specification, so that parsing fs sets the fields. Fields of struct
+// type, such as muscle.Log, are bound as a flag for each of their exported fields,
+// named with the field's name as a prefix.
+//
+// Flag names are the kebab-case forms of the field names, for example the flag for
+// MaxDuration is max-duration and the flag for the File field of Log is log-file. The
+// usage text for a flag is the value of the field's "doc" tag.
+//
+// Fields may be strings, booleans, integers, floating point numbers, time.Duration
+// values, values of types implementing encoding.TextUnmarshaler, or slices of these.
+// Slice fields are bound as repeatable flags; the first use of the flag replaces the
+// field's value and subsequent uses append to it.
+func BindFlags(fs *flag.FlagSet, cb CommandBuilder) error {
+	v := reflect.ValueOf(cb)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.New("external: not a pointer to a struct")
+	}
+	v = v.Elem()
+	specs, err := fields(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range specs {
+		tf := v.Type().Field(f.index)
+		err = bind(fs, "", tf, v.Field(f.index))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bind registers a flag in fs for the field tf holding v, or a flag for each exported
+// field of v if v is a struct that is not a time or text value.
+func bind(fs *flag.FlagSet, prefix string, tf reflect.StructField, v reflect.Value) error {
+	name := prefix + kebab(tf.Name)
+	if v.Kind() == reflect.Struct && !isScalar(v.Type()) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			err := bind(fs, name+"-", t.Field(i), v.Field(i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t := v.Type()
+	list := t.Kind() == reflect.Slice && !isScalar(t)
+	if list {
+		t = t.Elem()
+	}
+	if !isScalar(t) {
+		return fmt.Errorf("external: cannot bind field %s of type %s", tf.Name, v.Type())
+	}
+	if fs.Lookup(name) != nil {
+		return fmt.Errorf("external: flag %s for field %s already defined", name, tf.Name)
+	}
+	fs.Var(&flagValue{v: v, list: list}, name, tf.Tag.Get("doc"))
+	return nil
+}
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isScalar returns whether a single flag value of type t can be parsed.
+func isScalar(t reflect.Type) bool {
+	if t == durationType || reflect.PtrTo(t).Implements(textUnmarshaler) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// flagValue is a flag.Value that sets a field.
+type flagValue struct {
+	v    reflect.Value
+	list bool
+
+	// set indicates that the flag has
+	// been set since it was bound.
+	set bool
+}
+
+// String returns the value of the field, or the empty string if it is zero so that
+// zero defaults are not shown in flag usage.
+func (f *flagValue) String() string {
+	if f == nil || !f.v.IsValid() || isZero(f.v) {
+		return ""
+	}
+	if f.list {
+		s := make([]string, f.v.Len())
+		for i := range s {
+			s[i] = fmt.Sprint(f.v.Index(i).Interface())
+		}
+		return strings.Join(s, ",")
+	}
+	return fmt.Sprint(f.v.Interface())
+}
+
+func (f *flagValue) Set(s string) error {
+	t := f.v.Type()
+	if f.list {
+		t = t.Elem()
+	}
+	x, err := parseValue(t, s)
+	if err != nil {
+		return err
+	}
+	if !f.list {
+		f.v.Set(x)
+		return nil
+	}
+	if !f.set {
+		f.v.Set(reflect.MakeSlice(f.v.Type(), 0, 1))
+	}
+	f.v.Set(reflect.Append(f.v, x))
+	f.set = true
+	return nil
+}
+
+// IsBoolFlag allows boolean fields to be set without a value.
+func (f *flagValue) IsBoolFlag() bool {
+	return f.v.IsValid() && !f.list && f.v.Kind() == reflect.Bool && !reflect.PtrTo(f.v.Type()).Implements(textUnmarshaler)
+}
+
+// parseValue returns the value of type t represented by s.
+func parseValue(t reflect.Type, s string) (reflect.Value, error) {
+	if reflect.PtrTo(t).Implements(textUnmarshaler) {
+		p := reflect.New(t)
+		err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		return p.Elem(), err
+	}
+	if t == durationType {
+		d, err := time.ParseDuration(s)
+		return reflect.ValueOf(d), err
+	}
+	var (
+		x   interface{}
+		err error
+	)
+	switch t.Kind() {
+	case reflect.String:
+		x = s
+	case reflect.Bool:
+		x, err = strconv.ParseBool(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, err = strconv.ParseInt(s, 0, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		x, err = strconv.ParseUint(s, 0, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		x, err = strconv.ParseFloat(s, t.Bits())
+	default:
+		return reflect.Value{}, fmt.Errorf("external: cannot parse %s", t)
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(x).Convert(t), nil
+}
+
+// kebab returns the kebab-case form of the Go identifier name, for example
+// max-duration for MaxDuration and dp-part-tree for DPPartTree.
+func kebab(name string) string {
+	r := []rune(name)
+	var b strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) && i > 0 {
+			prev := r[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(r) && unicode.IsLower(r[i+1])) {
+				b.WriteByte('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,134 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/check.v1"
+)
+
+// mode is an enum that is set from its name.
+type mode int
+
+func (m mode) String() string { return [...]string{"fast", "slow"}[m] }
+
+func (m *mode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "fast":
+		*m = 0
+	case "slow":
+		*m = 1
+	default:
+		return errors.New("invalid mode " + strconv.Quote(string(text)))
+	}
+	return nil
+}
+
+type LogFile struct {
+	File   string
+	Append bool
+}
+
+type Flags struct {
+	Cmd         string        `buildarg:"{{if .}}{{.}}{{else}}tool{{end}}"`
+	Protein     bool          `flag:"-p" doc:"interpret sequences as proteins"`
+	MaxDuration time.Duration `buildarg:"{{if .}}-maxhours{{split}}{{hours .}}{{end}}" doc:"maximum run time"`
+	GapOpenCost float64       `opt:"--op"`
+	Blosum      byte          `opt:"--bl"`
+	Strand      int           `opt:"-s"`
+	Mode        mode          `opt:"-m"`
+	Seeds       []string      `opt:"--seed,repeat"`
+	Pair        []int         `opt:"-x"`
+	DPPartTree  bool          `flag:"--dpparttree"`
+	Log         LogFile       `buildarg:"{{if .File}}-log{{if .Append}}a{{end}}{{split}}{{.File}}{{end}}"`
+	Marker      struct{}      `buildarg:"sub"`
+	Untagged    int
+	InFiles     []string `pos:""`
+}
+
+func (f Flags) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+func (s *S) TestBindFlags(c *check.C) {
+	var f Flags
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	c.Assert(BindFlags(fs, &f), check.Equals, nil)
+
+	var names []string
+	fs.VisitAll(func(fl *flag.Flag) { names = append(names, fl.Name) })
+	c.Check(names, check.DeepEquals, []string{
+		"blosum", "cmd", "dp-part-tree", "gap-open-cost", "in-files", "log-append",
+		"log-file", "max-duration", "mode", "pair", "protein", "seeds", "strand",
+	})
+	c.Check(fs.Lookup("protein").Usage, check.Equals, "interpret sequences as proteins")
+
+	err := fs.Parse([]string{
+		"-protein", "-max-duration", "90m", "-gap-open-cost", "1.5", "-blosum", "62",
+		"-strand", "-1", "-mode", "slow", "-seeds", "a", "-seeds", "b", "-pair", "1", "-pair", "2",
+		"-dp-part-tree", "-log-file", "run.log", "-log-append", "-in-files", "in.fa",
+	})
+	c.Assert(err, check.Equals, nil)
+	c.Check(f, check.DeepEquals, Flags{
+		Protein:     true,
+		MaxDuration: 90 * time.Minute,
+		GapOpenCost: 1.5,
+		Blosum:      62,
+		Strand:      -1,
+		Mode:        1,
+		Seeds:       []string{"a", "b"},
+		Pair:        []int{1, 2},
+		DPPartTree:  true,
+		Log:         LogFile{File: "run.log", Append: true},
+		InFiles:     []string{"in.fa"},
+	})
+	args, err := Build(f)
+	c.Check(err, check.Equals, nil)
+	c.Check(args, check.DeepEquals, []string{
+		"tool", "-p", "-maxhours", "1.5", "--op", "1.5", "--bl", "62", "-s", "-1", "-m", "slow",
+		"--seed", "a", "--seed", "b", "-x", "1", "2", "--dpparttree", "-loga", "run.log", "sub", "in.fa",
+	})
+
+	// Repeated flags replace default values.
+	f = Flags{Seeds: []string{"default"}}
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	c.Assert(BindFlags(fs, &f), check.Equals, nil)
+	c.Check(fs.Lookup("seeds").DefValue, check.Equals, "default")
+	c.Assert(fs.Parse([]string{"-seeds", "x", "-seeds", "y"}), check.Equals, nil)
+	c.Check(f.Seeds, check.DeepEquals, []string{"x", "y"})
+
+	var buf bytes.Buffer
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&buf)
+	c.Assert(BindFlags(fs, &f), check.Equals, nil)
+	err = fs.Parse([]string{"-mode", "medium"})
+	c.Check(err, check.ErrorMatches, `invalid value "medium" for flag -mode: invalid mode "medium"`)
+	c.Check(strings.Contains(buf.String(), "maximum run time"), check.Equals, true)
+
+	c.Check(BindFlags(flag.NewFlagSet("test", flag.ContinueOnError), f), check.ErrorMatches, "external: not a pointer to a struct")
+	c.Check(BindFlags(flag.NewFlagSet("test", flag.ContinueOnError), &struct {
+		Ls
+		Ch chan int `buildarg:"{{.}}"`
+	}{}), check.ErrorMatches, "external: cannot bind field Ch of type chan int")
+}
+
+func (s *S) TestKebab(c *check.C) {
+	for _, t := range []struct{ in, want string }{
+		{"Cmd", "cmd"},
+		{"MaxDuration", "max-duration"},
+		{"DPPartTree", "dp-part-tree"},
+		{"In1", "in1"},
+		{"InFormat", "in-format"},
+		{"LAMA", "lama"},
+		{"GapOpenCost", "gap-open-cost"},
+	} {
+		c.Check(kebab(t.in), check.Equals, t.want)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// checkDeps returns an error if a non-zero field of the struct v does not satisfy
+// the dependencies declared by its "requires" and "when" tags.
+func checkDeps(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tf := t.Field(i)
+		req, hasReq := tf.Tag.Lookup("requires")
+		when, hasWhen := tf.Tag.Lookup("when")
+		if !hasReq && !hasWhen {
+			continue
+		}
+		if tf.PkgPath != "" {
+			return fmt.Errorf("external: dependency tag on unexported field %s", tf.Name)
+		}
+		// Check that the tags are well formed even
+		// if the field is not set.
+		var (
+			groups [][]string
+			conds  []condition
+			err    error
+		)
+		if hasReq {
+			groups, err = requirements(t, tf.Name, req)
+			if err != nil {
+				return err
+			}
+		}
+		if hasWhen {
+			conds, err = conditions(t, tf.Name, when)
+			if err != nil {
+				return err
+			}
+		}
+		if isZero(v.Field(i)) {
+			continue
+		}
+
+		for _, g := range groups {
+			ok := false
+			for _, name := range g {
+				if !isZero(v.FieldByName(name)) {
+					ok = true
+					break
+				}
+			}
+			if !ok {
+				if len(g) == 1 {
+					return fmt.Errorf("external: field %s requires %s", tf.Name, g[0])
+				}
+				return fmt.Errorf("external: field %s requires one of %s", tf.Name, strings.Join(g, ", "))
+			}
+		}
+		for _, c := range conds {
+			ok, err := c.holds(v.FieldByName(c.field))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("external: field %s is only valid when %s is %s", tf.Name, c.field, strings.Join(c.values, " or "))
+			}
+		}
+	}
+	return nil
+}
+
+// requirements parses the requires tag of the named field. The tag holds a comma
+// separated list of groups of field names separated by '|'. At least one field of
+// each group must be non-zero.
+func requirements(t reflect.Type, name, tag string) ([][]string, error) {
+	var groups [][]string
+	for _, g := range strings.Split(tag, ",") {
+		alts := strings.Split(g, "|")
+		for _, a := range alts {
+			err := checkRef(t, name, a)
+			if err != nil {
+				return nil, err
+			}
+		}
+		groups = append(groups, alts)
+	}
+	return groups, nil
+}
+
+// condition is a requirement that a field has one of a set of values.
+type condition struct {
+	field  string
+	values []string
+}
+
+// conditions parses the when tag of the named field. The tag holds a comma separated
+// list of conditions of the form Field=value1|value2.
+func conditions(t reflect.Type, name, tag string) ([]condition, error) {
+	var conds []condition
+	for _, c := range strings.Split(tag, ",") {
+		i := strings.Index(c, "=")
+		if i < 0 || i == len(c)-1 {
+			return nil, fmt.Errorf("external: invalid when condition %q on field %s", c, name)
+		}
+		err := checkRef(t, name, c[:i])
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, condition{field: c[:i], values: strings.Split(c[i+1:], "|")})
+	}
+	return conds, nil
+}
+
+// holds returns whether the value v matches one of the values of c. A value matches
+// if it is equal to the text or the %v representation of v.
+func (c condition) holds(v reflect.Value) (bool, error) {
+	t, err := text(v.Interface())
+	if err != nil {
+		return false, err
+	}
+	s := fmt.Sprint(v.Interface())
+	for _, want := range c.values {
+		if want == t || want == s {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkRef returns an error if ref does not name an exported field of t other than
+// the named field.
+func checkRef(t reflect.Type, name, ref string) error {
+	if ref == name {
+		return fmt.Errorf("external: field %s depends on itself", name)
+	}
+	f, ok := t.FieldByName(ref)
+	if !ok || f.PkgPath != "" {
+		return fmt.Errorf("external: field %s depends on unknown field %q", name, ref)
+	}
+	return nil
+}
+
+// isZero returns whether v is the zero value of its type, or an empty array, slice
+// or map.
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
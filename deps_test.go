@@ -0,0 +1,74 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"os/exec"
+
+	"gopkg.in/check.v1"
+)
+
+type Deps struct {
+	Profile bool     `flag:"-profile" requires:"In1,In2"`
+	In1     string   `opt:"-in1"`
+	In2     string   `opt:"-in2"`
+	Size    int      `opt:"--size" requires:"Tree|Guide"`
+	Tree    bool     `flag:"--tree"`
+	Guide   []string `opt:"--guide"`
+	Gamma   float64  `opt:"-g" when:"Type=5|6|high"`
+	Type    level    `opt:"-j"`
+	Both    bool     `flag:"-b" requires:"In1" when:"Type=1,Tree=true"`
+}
+
+func (d Deps) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+type unknownDep struct {
+	Ls
+	A bool `requires:"B"`
+}
+
+type selfDep struct {
+	Ls
+	A bool `requires:"A"`
+}
+
+type badWhen struct {
+	Ls
+	A bool `when:"Ls"`
+}
+
+func (s *S) TestDeps(c *check.C) {
+	for _, t := range []struct {
+		d   Deps
+		err string
+	}{
+		{d: Deps{}},
+		{d: Deps{Profile: true, In1: "a", In2: "b"}},
+		{d: Deps{Profile: true, In1: "a"}, err: "external: field Profile requires In2"},
+		{d: Deps{Profile: true}, err: "external: field Profile requires In1"},
+		{d: Deps{Size: 2, Tree: true}},
+		{d: Deps{Size: 2, Guide: []string{"g"}}},
+		{d: Deps{Size: 2, Guide: []string{}}, err: "external: field Size requires one of Tree, Guide"},
+		{d: Deps{Gamma: 1, Type: low}, err: "external: field Gamma is only valid when Type is 5 or 6 or high"},
+		{d: Deps{Gamma: 1, Type: high}},
+		{d: Deps{Gamma: 1}, err: "external: field Gamma is only valid when Type is 5 or 6 or high"},
+		{d: Deps{Both: true, In1: "a", Type: low, Tree: true}},
+		{d: Deps{Both: true, In1: "a", Type: low}, err: "external: field Both is only valid when Tree is true"},
+	} {
+		_, err := Build(t.d)
+		if t.err == "" {
+			c.Check(err, check.Equals, nil, check.Commentf("%+v", t.d))
+		} else {
+			c.Check(err, check.ErrorMatches, t.err, check.Commentf("%+v", t.d))
+		}
+	}
+
+	_, err := Build(unknownDep{})
+	c.Check(err, check.ErrorMatches, `external: field A depends on unknown field "B"`)
+	_, err = Build(selfDep{})
+	c.Check(err, check.ErrorMatches, "external: field A depends on itself")
+	_, err = Build(badWhen{})
+	c.Check(err, check.ErrorMatches, `external: invalid when condition "Ls" on field A`)
+}
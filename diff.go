@@ -0,0 +1,91 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// Change is a difference in the value of a field between two CommandBuilders.
+type Change struct {
+	// Field is the name of the field.
+	Field string `json:"field"`
+
+	// Old and New are the values of the field.
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+
+	// OldArgs and NewArgs are the command line
+	// arguments given by the field.
+	OldArgs []string `json:"old_args"`
+	NewArgs []string `json:"new_args"`
+}
+
+// Changes is a set of field changes.
+type Changes []Change
+
+// String returns a human readable description of the changes, giving the old and new
+// values of each changed field followed by the arguments removed and added.
+func (c Changes) String() string {
+	var buf bytes.Buffer
+	for _, ch := range c {
+		fmt.Fprintf(&buf, "%s: %s -> %s\n", ch.Field, formatValue(ch.Old), formatValue(ch.New))
+		if len(ch.OldArgs) != 0 {
+			fmt.Fprintf(&buf, "\t- %s\n", strings.Join(ch.OldArgs, " "))
+		}
+		if len(ch.NewArgs) != 0 {
+			fmt.Fprintf(&buf, "\t+ %s\n", strings.Join(ch.NewArgs, " "))
+		}
+	}
+	return buf.String()
+}
+
+// Diff compares the fields of a and b that have argument specifications and returns
+// the fields that differ in argument order. The CommandBuilders must have the same
+// type. The arguments given by each field are built as described in the documentation
+// for Build.
+func Diff(a, b CommandBuilder, funcs ...template.FuncMap) (Changes, error) {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return nil, fmt.Errorf("external: cannot compare %T and %T", a, b)
+	}
+	type result struct {
+		value interface{}
+		args  []string
+	}
+	var old []result
+	err := build(a, funcs, func(_ field, v reflect.Value, args []string) {
+		old = append(old, result{value: v.Interface(), args: args})
+	})
+	if err != nil {
+		return nil, err
+	}
+	var (
+		changes Changes
+		i       int
+	)
+	err = build(b, funcs, func(f field, v reflect.Value, args []string) {
+		o := old[i]
+		i++
+		value := v.Interface()
+		if reflect.DeepEqual(o.value, value) {
+			return
+		}
+		changes = append(changes, Change{
+			Field:   f.name,
+			Old:     o.value,
+			New:     value,
+			OldArgs: o.args,
+			NewArgs: args,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,45 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"encoding/json"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestDiff(c *check.C) {
+	a := Texts{Level: low, Shape: 1, Quoted: []string{"x"}}
+	b := Texts{Level: high, Shape: 1, Levels: []level{off, high}}
+
+	changes, err := Diff(a, a)
+	c.Check(err, check.Equals, nil)
+	c.Check(changes, check.HasLen, 0)
+
+	changes, err = Diff(a, &b)
+	c.Check(err, check.ErrorMatches, `external: cannot compare external.Texts and \*external.Texts`)
+
+	changes, err = Diff(a, b)
+	c.Assert(err, check.Equals, nil)
+	c.Check(changes, check.DeepEquals, Changes{
+		{Field: "Level", Old: low, New: high, OldArgs: []string{"-l", "1"}, NewArgs: []string{"-l", "2"}},
+		{Field: "Levels", Old: []level(nil), New: []level{off, high}, NewArgs: []string{"-L=0,2"}},
+		{Field: "Quoted", Old: []string{"x"}, New: []string(nil), OldArgs: []string{`"x"`}},
+	})
+	c.Check(changes.String(), check.Equals, `Level: low -> high
+	- -l 1
+	+ -l 2
+Levels: [] -> [off high]
+	+ -L=0,2
+Quoted: [x] -> []
+	- "x"
+`)
+
+	j, err := json.Marshal(changes[:2])
+	c.Assert(err, check.Equals, nil)
+	c.Check(string(j), check.Equals, `[`+
+		`{"field":"Level","old":"1","new":"2","old_args":["-l","1"],"new_args":["-l","2"]},`+
+		`{"field":"Levels","old":null,"new":["0","2"],"old_args":null,"new_args":["-L=0,2"]}]`)
+}
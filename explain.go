@@ -0,0 +1,111 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// Source describes a field of a CommandBuilder that has an argument specification.
+type Source struct {
+	// Field is the name of the field.
+	Field string
+
+	// Tag is the struct tag of the field.
+	Tag reflect.StructTag
+
+	// Value is the value of the field.
+	Value interface{}
+}
+
+// Arg is a command line argument annotated with the field that gave it.
+type Arg struct {
+	Arg string
+	Source
+}
+
+// Explanation is a set of command line arguments annotated with the fields that gave
+// them, and the fields that gave no arguments.
+type Explanation struct {
+	// Args holds the arguments in order.
+	Args []Arg
+
+	// Omitted holds the fields that gave no arguments
+	// in argument order.
+	Omitted []Source
+}
+
+// Explain builds a set of command line args from cb in the same way as Build and returns
+// each argument annotated with the field that gave it.
+func Explain(cb CommandBuilder, funcs ...template.FuncMap) (Explanation, error) {
+	var e Explanation
+	err := build(cb, funcs, func(f field, v reflect.Value, args []string) {
+		src := Source{Field: f.name, Tag: f.tag, Value: v.Interface()}
+		if len(args) == 0 {
+			e.Omitted = append(e.Omitted, src)
+			return
+		}
+		for _, a := range args {
+			e.Args = append(e.Args, Arg{Arg: a, Source: src})
+		}
+	})
+	return e, err
+}
+
+// Strings returns the arguments held by e.
+func (e Explanation) Strings() []string {
+	args := make([]string, len(e.Args))
+	for i, a := range e.Args {
+		args[i] = a.Arg
+	}
+	return args
+}
+
+// String returns a table of the arguments held by e and the fields that gave them,
+// followed by the omitted fields. Omitted fields are marked as zero if their value
+// is the zero value of its type or is empty.
+func (e Explanation) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ARG\tFIELD\tVALUE")
+	for _, a := range e.Args {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", a.Arg, a.Field, formatValue(a.Value))
+	}
+	w.Flush()
+	if len(e.Omitted) == 0 {
+		return buf.String()
+	}
+	var zero, empty []string
+	for _, o := range e.Omitted {
+		if isZero(reflect.ValueOf(o.Value)) {
+			zero = append(zero, o.Field)
+		} else {
+			empty = append(empty, fmt.Sprintf("%s=%s", o.Field, formatValue(o.Value)))
+		}
+	}
+	if len(zero) != 0 {
+		fmt.Fprintf(&buf, "omitted (zero): %s\n", strings.Join(zero, ", "))
+	}
+	if len(empty) != 0 {
+		fmt.Fprintf(&buf, "omitted (no arguments): %s\n", strings.Join(empty, ", "))
+	}
+	return buf.String()
+}
+
+// formatValue returns a string representation of a field value for display.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,68 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"reflect"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestExplain(c *check.C) {
+	cb := Compact{
+		Protein: true,
+		Seed:    "1110",
+		Thread:  4,
+		DB:      "db",
+		InFiles: []string{"a.fa", "b.fa"},
+	}
+	e, err := Explain(cb)
+	c.Assert(err, check.Equals, nil)
+	args, err := Build(cb)
+	c.Assert(err, check.Equals, nil)
+	c.Check(e.Strings(), check.DeepEquals, args)
+
+	typ := reflect.TypeOf(cb)
+	source := func(name string, value interface{}) Source {
+		f, _ := typ.FieldByName(name)
+		return Source{Field: name, Tag: f.Tag, Value: value}
+	}
+	c.Check(e.Args, check.DeepEquals, []Arg{
+		{Arg: "tool", Source: source("Cmd", "")},
+		{Arg: "-p", Source: source("Protein", true)},
+		{Arg: "-m", Source: source("Seed", "1110")},
+		{Arg: "1110", Source: source("Seed", "1110")},
+		{Arg: "--thread=4", Source: source("Thread", 4)},
+		{Arg: "db", Source: source("DB", "db")},
+		{Arg: "a.fa", Source: source("InFiles", []string{"a.fa", "b.fa"})},
+		{Arg: "b.fa", Source: source("InFiles", []string{"a.fa", "b.fa"})},
+	})
+	c.Check(e.Omitted, check.HasLen, 5)
+
+	c.Check(e.String(), check.Equals, `ARG         FIELD    VALUE
+tool        Cmd      ""
+-p          Protein  true
+-m          Seed     "1110"
+1110        Seed     "1110"
+--thread=4  Thread   4
+db          DB       "db"
+a.fa        InFiles  [a.fa b.fa]
+b.fa        InFiles  [a.fa b.fa]
+omitted (zero): Weight, Seeds, Pair, Exclude, List
+`)
+
+	e, err = Explain(struct {
+		Ls
+		Cmd    string   `buildarg:"{{if .}}{{.}}{{else}}ls{{end}}"`
+		Glob   []string `pos:""`
+		Hidden int      `buildarg:"{{if false}}-h{{end}}"`
+	}{Hidden: 3})
+	c.Assert(err, check.Equals, nil)
+	c.Check(e.String(), check.Equals, `ARG  FIELD  VALUE
+ls   Cmd    ""
+omitted (zero): Glob
+omitted (no arguments): Hidden=3
+`)
+}
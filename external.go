@@ -7,6 +7,7 @@ package external
 
 import (
 	"bytes"
+	"encoding"
 	"errors"
 	"fmt"
 	"os/exec"
@@ -24,27 +25,47 @@ type CommandBuilder interface {
 
 // mprintf applies Sprintf with the provided format to each element of an array, slice or map, or
 // pointer to any of these, otherwise if returns the fmt.Sprintf representation of the underlying
-// value with the given format.
-func mprintf(format string, value interface{}) interface{} {
+// value with the given format. Values implementing encoding.TextMarshaler are formatted as their
+// text.
+func mprintf(format string, value interface{}) (interface{}, error) {
 	rv := reflect.ValueOf(value)
 	if kind := rv.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
 		rv = rv.Elem()
 	}
+	sprintf := func(v reflect.Value) (string, error) {
+		e := v.Interface()
+		if m, ok := e.(encoding.TextMarshaler); ok {
+			t, err := text(m)
+			if err != nil {
+				return "", err
+			}
+			e = t
+		}
+		return fmt.Sprintf(format, e), nil
+	}
 	switch rv.Kind() {
 	case reflect.Array, reflect.Slice:
 		q := make([]string, rv.Len())
 		for i := 0; i < rv.Len(); i++ {
-			q[i] = fmt.Sprintf(format, rv.Index(i).Interface())
+			var err error
+			q[i], err = sprintf(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
 		}
-		return q
+		return q, nil
 	case reflect.Map:
 		q := make([]string, rv.Len())
 		for i, k := range rv.MapKeys() {
-			q[i] = fmt.Sprintf(format, rv.MapIndex(k).Interface())
+			var err error
+			q[i], err = sprintf(rv.MapIndex(k))
+			if err != nil {
+				return nil, err
+			}
 		}
-		return q
+		return q, nil
 	default:
-		return fmt.Sprintf(format, rv.Interface())
+		return sprintf(rv)
 	}
 
 	panic("cannot reach")
@@ -52,34 +73,41 @@ func mprintf(format string, value interface{}) interface{} {
 
 // quote wraps in quotes an item or each element of an array, slice or map by calling mprintf with
 // "%q" as the format.
-func quote(value interface{}) interface{} { return mprintf("%q", value) }
+func quote(value interface{}) (interface{}, error) { return mprintf("%q", value) }
 
 // join performs the genric equivalent of a call to strings.Join with the parameter order
 // reversed to allow use in a template pipeline.
-func join(sep string, a interface{}) string {
+func join(sep string, a interface{}) (string, error) {
 	rv := reflect.ValueOf(a)
 	if kind := rv.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
 		rv = rv.Elem()
 	}
+	var elems []reflect.Value
 	switch rv.Kind() {
 	case reflect.Array, reflect.Slice:
-		cs := make([]string, rv.Len())
 		for i := 0; i < rv.Len(); i++ {
-			cs[i] = fmt.Sprint(rv.Index(i))
+			elems = append(elems, rv.Index(i))
 		}
-		return strings.Join(cs, sep)
 	case reflect.Map:
-		cs := make([]string, rv.Len())
-		for i, k := range rv.MapKeys() {
-			cs[i] = fmt.Sprint(rv.MapIndex(k))
+		for _, k := range rv.MapKeys() {
+			elems = append(elems, rv.MapIndex(k))
+		}
+	default:
+		return text(a)
+	}
+	cs := make([]string, len(elems))
+	for i, e := range elems {
+		var err error
+		cs[i], err = text(e.Interface())
+		if err != nil {
+			return "", err
 		}
-		return strings.Join(cs, sep)
 	}
-	return fmt.Sprint(a)
+	return strings.Join(cs, sep), nil
 }
 
 // splitargs is an alias to join with sep equal to the split tag.
-func splitargs(a interface{}) string { return join(split(), a) }
+func splitargs(a interface{}) (string, error) { return join(split(), a) }
 
 // split includes the split tag, "\x00".
 func split() string { return string(rune(0)) }
@@ -91,6 +119,60 @@ func split() string { return string(rune(0)) }
 // An argument split tag, "\x00", can be used to denote separation of elements of the args array
 // within any single parameter specification. Template functions can be provided via funcs.
 //
+// Common argument forms may instead be specified with the compact tags "flag", "opt",
+// "pos" and "extra":
+//  flag:"-p"
+//	Equivalent to buildarg:"{{if .}}-p{{end}}".
+//  opt:"-m"
+//	Equivalent to buildarg:"{{if .}}-m{{split}}{{.}}{{end}}". An array or slice field
+//	gives -m followed by each element.
+//  opt:"--seed,repeat"
+//	Gives --seed followed by the element for each element of an array or slice field.
+//  opt:"--thread,eq"
+//	Gives --thread=value. The eq option may be combined with repeat. An array or slice
+//	field without repeat gives the elements joined by commas.
+//  pos:""
+//	Equivalent to buildarg:"{{.}}", or buildarg:"{{args .}}" for an array or slice field.
+//  extra:""
+//	Gives each element of a []string field unchanged. It allows arguments that are not
+//	modelled by other fields to be passed to a tool. It is an error for an element to
+//	be a flag that appears in the specification of another field.
+//
+// Arguments are emitted in field declaration order, except that the fields
+// holding extra arguments follow other non-positional fields, and the fields
+// holding positional arguments, those with a pos tag, follow all other fields.
+// The order of a field's arguments may be given explicitly with an "order" tag
+// holding a non-zero integer. The first field with an argument specification
+// holds the command and always comes first unless it has an order tag or a pos
+// or extra tag. Other fields with a negative order precede all unordered fields,
+// and fields with a positive order follow unordered non-positional and extra
+// argument fields and precede unordered positional fields. It is an error for
+// two fields to have the same order.
+//
+// String values, and the string elements of array and slice values, may not contain
+// the split tag. The values of fields with a "path" tag that begin with '-', other
+// than the standard stream name "-", are rewritten to begin with "./" so that they
+// are not read as flags, or are rejected if the tag has the strict option, as in
+// path:"in,strict".
+//
+// Dependencies between fields may be declared with "requires" and "when" tags, which
+// are checked when the tagged field is not the zero value of its type or empty:
+//  requires:"In1,In2"
+//	The fields In1 and In2 must both be set.
+//  requires:"Partree|DPPartTree"
+//	At least one of Partree and DPPartTree must be set. Alternatives may be combined
+//	with the comma form, for example requires:"In,Tree|Guide".
+//  when:"OutputType=5|6"
+//	The field is only valid when the text or %v representation of OutputType is 5 or
+//	6. Several comma separated conditions must all hold.
+//
+// Field values, and the elements of array and slice field values, that implement
+// encoding.TextMarshaler are rendered as the text returned by MarshalText. Zero values
+// are passed to the template unchanged so that {{if .}} omits them. Other values are
+// rendered with their %v representation, so types implementing fmt.Stringer are
+// rendered by their String method. The helper functions below render values in the
+// same way.
+//
 // Four convenience functions are provided:
 //  args
 //	Joins %v representation of elements of an array, slice or map, or reference to any of
@@ -109,55 +191,96 @@ func split() string { return string(rune(0)) }
 //  split
 //	Includes a split tag in a pipeline.
 //
+// Further functions are provided for common argument forms:
+//  hours, minutes, seconds
+//	Return a time.Duration as a floating point number of hours, minutes or seconds.
+//  kv
+//	Returns its first argument and the %v representation of its second argument joined
+//	by "=", for example {{kv "--seed" .}} gives --seed=1.
+//  abs, base, ext
+//	Apply filepath.Abs, filepath.Base or filepath.Ext to a path or each element of an
+//	array or slice of paths, or reference to any of these.
+//  default
+//	Returns its second argument, or its first argument if the second is the zero value
+//	of its type or is empty, for example {{. | default "auto"}}.
+//  yesno
+//	Returns "yes" for true and "no" for false.
+//  each
+//	Executes the template given as its first argument for each element of an array or
+//	slice, or reference to either of these, joining the results with the split tag. The
+//	template is executed with an Element holding the index and value of each element,
+//	for example {{each "-i{{.Index}}{{split}}{{.Value}}" .}}.
+//
 //  Note that args, join, mprintf and quote will return randomly ordered arguments if a map is used
 //  as a template input.
 func Build(cb CommandBuilder, funcs ...template.FuncMap) (args []string, err error) {
+	err = build(cb, funcs, func(_ field, _ reflect.Value, fargs []string) {
+		args = append(args, fargs...)
+	})
+	return args, err
+}
+
+// build builds the args for cb as described in the documentation for Build, calling
+// fn with each field that has an argument specification, its value and the args it
+// gives, in argument order.
+func build(cb CommandBuilder, funcs []template.FuncMap, fn func(f field, v reflect.Value, args []string)) error {
 	v := reflect.ValueOf(cb)
 	if kind := v.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
 		v = v.Elem()
 	}
 	if v.Kind() != reflect.Struct {
-		return nil, errors.New("external: not a struct")
+		return errors.New("external: not a struct")
+	}
+	fs, err := fields(v.Type())
+	if err != nil {
+		return err
+	}
+	err = checkExtra(fs, v)
+	if err != nil {
+		return err
+	}
+	err = checkDeps(v)
+	if err != nil {
+		return err
 	}
-	n := v.NumField()
-	t := v.Type()
 	b := &bytes.Buffer{}
-	for i := 0; i < n; i++ {
-		tf := t.Field(i)
-		if tf.PkgPath != "" && !tf.Anonymous {
-			continue
+	for _, f := range fs {
+		tmpl := template.New(f.name)
+		tmpl.Funcs(builtins(funcs))
+		for _, fm := range funcs {
+			tmpl.Funcs(fm)
 		}
-		tag := tf.Tag.Get("buildarg")
-		if tag != "" {
-			tmpl := template.New(tf.Name)
-			tmpl.Funcs(template.FuncMap{
-				"join":    join,
-				"args":    splitargs,
-				"split":   split,
-				"quote":   quote,
-				"mprintf": mprintf,
-			})
-			for _, fn := range funcs {
-				tmpl.Funcs(fn)
-			}
 
-			template.Must(tmpl.Parse(tag))
-			err = tmpl.Execute(b, v.Field(i).Interface())
-			if err != nil {
-				return args, err
-			}
-			if b.Len() > 0 {
-				for _, arg := range strings.Split(b.String(), string(rune(0))) {
-					if len(arg) > 0 {
-						args = append(args, arg)
-					}
+		_, err = tmpl.Parse(f.text)
+		if err != nil {
+			return err
+		}
+		fv := v.Field(f.index)
+		value, err := marshaled(fv)
+		if err != nil {
+			return err
+		}
+		value, err = sanitize(f, value)
+		if err != nil {
+			return err
+		}
+		err = tmpl.Execute(b, value)
+		if err != nil {
+			return err
+		}
+		var args []string
+		if b.Len() > 0 {
+			for _, arg := range strings.Split(b.String(), string(rune(0))) {
+				if len(arg) > 0 {
+					args = append(args, arg)
 				}
 			}
-			b.Reset()
 		}
+		fn(f, fv, args)
+		b.Reset()
 	}
 
-	return
+	return nil
 }
 
 // Must is a helper that wraps a call to a function returning ([]string, error)
@@ -87,6 +87,7 @@ func (s *S) TestDu(c *check.C) {
 		"external_test.go",
 		"external_example_test.go",
 		".git",
+		"testdata",
 	}
 	du, err := Du{
 		Exclude: files,
@@ -100,6 +101,7 @@ func (s *S) TestDu(c *check.C) {
 		"--exclude=external_test.go",
 		"--exclude=external_example_test.go",
 		"--exclude=.git",
+		"--exclude=testdata",
 	})
 	du.Stdout = &bytes.Buffer{}
 	du.Stderr = &bytes.Buffer{}
@@ -0,0 +1,259 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// builtins returns the template functions provided by Build. The each function
+// executes its templates with the functions in the returned map and any
+// functions in funcs.
+func builtins(funcs []template.FuncMap) template.FuncMap {
+	fm := template.FuncMap{
+		"join":    join,
+		"args":    splitargs,
+		"split":   split,
+		"quote":   quote,
+		"mprintf": mprintf,
+
+		"hours":   hours,
+		"minutes": minutes,
+		"seconds": seconds,
+		"kv":      kv,
+		"abs":     abs,
+		"base":    base,
+		"ext":     ext,
+		"default": dflt,
+		"yesno":   yesno,
+	}
+	fm["each"] = func(text string, value interface{}) (string, error) {
+		return each(text, value, fm, funcs)
+	}
+	return fm
+}
+
+// duration returns the time.Duration held by value.
+func duration(value interface{}) (time.Duration, error) {
+	rv := reflect.ValueOf(value)
+	if kind := rv.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || rv.Type() != reflect.TypeOf(time.Duration(0)) {
+		return 0, fmt.Errorf("external: %T is not a time.Duration", value)
+	}
+	return time.Duration(rv.Int()), nil
+}
+
+// hours returns a time.Duration as a floating point number of hours.
+func hours(value interface{}) (float64, error) {
+	d, err := duration(value)
+	return d.Hours(), err
+}
+
+// minutes returns a time.Duration as a floating point number of minutes.
+func minutes(value interface{}) (float64, error) {
+	d, err := duration(value)
+	return d.Minutes(), err
+}
+
+// seconds returns a time.Duration as a floating point number of seconds.
+func seconds(value interface{}) (float64, error) {
+	d, err := duration(value)
+	return d.Seconds(), err
+}
+
+// kv returns key=value for the text representation of value.
+func kv(key string, value interface{}) (string, error) {
+	t, err := text(value)
+	if err != nil {
+		return "", err
+	}
+	return key + "=" + t, nil
+}
+
+var textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// text returns the text representation of value. Values implementing
+// encoding.TextMarshaler are rendered with MarshalText, and other values
+// with their %v representation, so values implementing fmt.Stringer are
+// rendered with String.
+func text(value interface{}) (string, error) {
+	if m, ok := value.(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("external: error calling MarshalText for type %T: %v", value, err)
+		}
+		return string(b), nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// marshaled returns the value of a field to be passed to its template. Non-zero
+// values of types implementing encoding.TextMarshaler are replaced by their text,
+// and arrays and slices of such types by a slice of the texts of their elements.
+// Zero values are passed unchanged so that tests of the value in a template, such
+// as {{if .}}, are not affected.
+func marshaled(v reflect.Value) (interface{}, error) {
+	t := v.Type()
+	switch {
+	case t.Implements(textMarshaler):
+		if v.IsZero() {
+			break
+		}
+		return text(v.Interface())
+	case isList(t) && t.Elem().Implements(textMarshaler):
+		s := make([]string, v.Len())
+		for i := range s {
+			var err error
+			s[i], err = text(v.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	}
+	return v.Interface(), nil
+}
+
+// mapstrings applies fn to a string or each element of an array or slice of strings,
+// or a reference to any of these.
+func mapstrings(fn func(string) (string, error), value interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(value)
+	if kind := rv.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return fn(rv.String())
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.String {
+			s := make([]string, rv.Len())
+			for i := range s {
+				var err error
+				s[i], err = fn(rv.Index(i).String())
+				if err != nil {
+					return nil, err
+				}
+			}
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("external: %T is not a string type", value)
+}
+
+// abs returns the absolute representation of a path or each element of an array or
+// slice of paths.
+func abs(value interface{}) (interface{}, error) {
+	return mapstrings(func(p string) (string, error) {
+		if p == "" {
+			return "", nil
+		}
+		return filepath.Abs(p)
+	}, value)
+}
+
+// base returns the last element of a path or each element of an array or slice of paths.
+func base(value interface{}) (interface{}, error) {
+	return mapstrings(func(p string) (string, error) {
+		if p == "" {
+			return "", nil
+		}
+		return filepath.Base(p), nil
+	}, value)
+}
+
+// ext returns the file name extension of a path or each element of an array or slice
+// of paths.
+func ext(value interface{}) (interface{}, error) {
+	return mapstrings(func(p string) (string, error) {
+		return filepath.Ext(p), nil
+	}, value)
+}
+
+// dflt returns value, or def if value is the zero value for its type or an empty
+// array, slice or map. The parameter order allows use in a template pipeline.
+func dflt(def, value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return def
+	}
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		if rv.Len() == 0 {
+			return def
+		}
+	default:
+		if rv.IsZero() {
+			return def
+		}
+	}
+	return value
+}
+
+// yesno returns "yes" if value is true and "no" otherwise.
+func yesno(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// Element is the value passed to the template executed by the each template function
+// for each element of a value.
+type Element struct {
+	Index int
+	Value interface{}
+}
+
+// each executes the template text for each element of an array or slice, or reference
+// to either of these, or for the value itself otherwise, joining the results with
+// the split tag.
+func each(text string, value interface{}, fm template.FuncMap, funcs []template.FuncMap) (string, error) {
+	tmpl := template.New("each").Funcs(fm)
+	for _, fn := range funcs {
+		tmpl.Funcs(fn)
+	}
+	_, err := tmpl.Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var elems []interface{}
+	rv := reflect.ValueOf(value)
+	if kind := rv.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		elems = make([]interface{}, rv.Len())
+		for i := range elems {
+			elems[i] = rv.Index(i).Interface()
+		}
+	default:
+		elems = []interface{}{value}
+	}
+
+	var (
+		buf bytes.Buffer
+		out []string
+	)
+	for i, e := range elems {
+		err = tmpl.Execute(&buf, Element{Index: i, Value: e})
+		if err != nil {
+			return "", err
+		}
+		out = append(out, buf.String())
+		buf.Reset()
+	}
+	return strings.Join(out, split()), nil
+}
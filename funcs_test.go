@@ -0,0 +1,218 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/check.v1"
+)
+
+type Funcs struct {
+	Hours    time.Duration `buildarg:"{{if .}}-h{{split}}{{hours .}}{{end}}"`
+	Minutes  time.Duration `buildarg:"{{if .}}-m{{split}}{{minutes .}}{{end}}"`
+	Seconds  time.Duration `buildarg:"{{if .}}-s{{split}}{{seconds .}}{{end}}"`
+	Seed     int           `buildarg:"{{if .}}{{kv \"--seed\" .}}{{end}}"`
+	Abs      string        `buildarg:"{{if .}}{{abs .}}{{end}}"`
+	AbsAll   []string      `buildarg:"{{if .}}{{abs . | args}}{{end}}"`
+	Base     []string      `buildarg:"{{if .}}{{base . | args}}{{end}}"`
+	Ext      string        `buildarg:"{{if .}}{{ext .}}{{end}}"`
+	Method   string        `buildarg:"--method={{. | default \"auto\"}}"`
+	Strict   bool          `buildarg:"--strict={{yesno .}}"`
+	Inputs   []string      `buildarg:"{{each \"-i{{.Index}}{{split}}{{.Value}}\" .}}"`
+	Weights  []float64     `buildarg:"{{each \"{{kv (printf \\\"-w%d\\\" .Index) .Value}}\" .}}"`
+	Optional string        `buildarg:"{{each \"{{with .Value}}-o{{split}}{{.}}{{end}}\" .}}"`
+}
+
+func (f Funcs) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+func (s *S) TestFuncs(c *check.C) {
+	wd, err := os.Getwd()
+	c.Assert(err, check.Equals, nil)
+	for _, t := range []struct {
+		f    Funcs
+		want []string
+	}{
+		{
+			f:    Funcs{},
+			want: []string{"--method=auto", "--strict=no"},
+		},
+		{
+			f:    Funcs{Hours: 90 * time.Minute},
+			want: []string{"-h", "1.5", "--method=auto", "--strict=no"},
+		},
+		{
+			f:    Funcs{Minutes: 90 * time.Second},
+			want: []string{"-m", "1.5", "--method=auto", "--strict=no"},
+		},
+		{
+			f:    Funcs{Seconds: 1500 * time.Millisecond},
+			want: []string{"-s", "1.5", "--method=auto", "--strict=no"},
+		},
+		{
+			f:    Funcs{Seed: 42},
+			want: []string{"--seed=42", "--method=auto", "--strict=no"},
+		},
+		{
+			f:    Funcs{Abs: "in.fa", AbsAll: []string{"a.fa", "/b.fa"}},
+			want: []string{filepath.Join(wd, "in.fa"), filepath.Join(wd, "a.fa"), "/b.fa", "--method=auto", "--strict=no"},
+		},
+		{
+			f:    Funcs{Base: []string{"/data/a.fa", "b.fa"}, Ext: "reads.fastq"},
+			want: []string{"a.fa", "b.fa", ".fastq", "--method=auto", "--strict=no"},
+		},
+		{
+			f:    Funcs{Method: "upgma", Strict: true},
+			want: []string{"--method=upgma", "--strict=yes"},
+		},
+		{
+			f:    Funcs{Inputs: []string{"a.fa", "b.fa"}},
+			want: []string{"--method=auto", "--strict=no", "-i0", "a.fa", "-i1", "b.fa"},
+		},
+		{
+			f:    Funcs{Weights: []float64{0.5, 2}},
+			want: []string{"--method=auto", "--strict=no", "-w0=0.5", "-w1=2"},
+		},
+		{
+			f:    Funcs{Optional: "out"},
+			want: []string{"--method=auto", "--strict=no", "-o", "out"},
+		},
+	} {
+		args, err := Build(t.f)
+		c.Check(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, t.want)
+	}
+
+	_, err = Build(struct {
+		Funcs
+		Bad int `buildarg:"{{hours .}}"`
+	}{})
+	c.Check(err, check.ErrorMatches, `.*external: int is not a time.Duration`)
+}
+
+func (s *S) TestFuncsDirect(c *check.C) {
+	d := 36 * time.Minute
+	h, err := hours(d)
+	c.Check(err, check.Equals, nil)
+	c.Check(h, check.Equals, 0.6)
+	h, err = hours(&d)
+	c.Check(err, check.Equals, nil)
+	c.Check(h, check.Equals, 0.6)
+	m, err := minutes(d)
+	c.Check(err, check.Equals, nil)
+	c.Check(m, check.Equals, 36.0)
+	sec, err := seconds(d)
+	c.Check(err, check.Equals, nil)
+	c.Check(sec, check.Equals, 2160.0)
+
+	k, err := kv("--key", "value")
+	c.Check(err, check.Equals, nil)
+	c.Check(k, check.Equals, "--key=value")
+	k, err = kv("-t", 1.5)
+	c.Check(err, check.Equals, nil)
+	c.Check(k, check.Equals, "-t=1.5")
+
+	b, err := base("/a/b/c.txt")
+	c.Check(err, check.Equals, nil)
+	c.Check(b, check.Equals, "c.txt")
+	e, err := ext([2]string{"a.tar.gz", "b"})
+	c.Check(err, check.Equals, nil)
+	c.Check(e, check.DeepEquals, []string{".gz", ""})
+	_, err = ext(1)
+	c.Check(err, check.ErrorMatches, "external: int is not a string type")
+
+	c.Check(dflt("x", ""), check.Equals, "x")
+	c.Check(dflt("x", "y"), check.Equals, "y")
+	c.Check(dflt(1, 0), check.Equals, 1)
+	c.Check(dflt("x", []string{}), check.Equals, "x")
+	c.Check(dflt("x", nil), check.Equals, "x")
+
+	c.Check(yesno(true), check.Equals, "yes")
+	c.Check(yesno(false), check.Equals, "no")
+}
+
+// level is an enum rendered as its number on the command line.
+type level int
+
+const (
+	off level = iota
+	low
+	high
+)
+
+func (l level) String() string { return [...]string{"off", "low", "high"}[l] }
+
+func (l level) MarshalText() ([]byte, error) {
+	if l > high {
+		return nil, errors.New("invalid level")
+	}
+	return []byte(strconv.Itoa(int(l))), nil
+}
+
+// shape is an enum rendered by its name.
+type shape int
+
+func (s shape) String() string { return [...]string{"none", "square", "circle"}[s] }
+
+type Texts struct {
+	Level  level    `opt:"-l"`
+	Levels []level  `opt:"-L,eq"`
+	Shape  shape    `opt:"--shape,eq"`
+	Pos    level    `buildarg:"{{.}}"`
+	Joined []level  `buildarg:"{{if .}}-j{{split}}{{join \":\" .}}{{end}}"`
+	KV     level    `buildarg:"{{if .}}{{kv \"--kv\" .}}{{end}}"`
+	Shapes []shape  `buildarg:"{{if .}}{{mprintf \"-s%v\" . | args}}{{end}}"`
+	Quoted []string `buildarg:"{{if .}}{{quote . | args}}{{end}}"`
+}
+
+func (t Texts) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+func (s *S) TestText(c *check.C) {
+	for _, t := range []struct {
+		t    Texts
+		want []string
+	}{
+		{
+			t:    Texts{},
+			want: []string{"off"},
+		},
+		{
+			t: Texts{
+				Level:  high,
+				Levels: []level{off, low},
+				Shape:  2,
+				Pos:    low,
+				Joined: []level{low, high},
+				KV:     high,
+				Shapes: []shape{1, 2},
+				Quoted: []string{"a b"},
+			},
+			want: []string{"-l", "2", "-L=0,1", "--shape=circle", "1", "-j", "1:2", "--kv=2", "-ssquare", "-scircle", `"a b"`},
+		},
+	} {
+		args, err := Build(t.t)
+		c.Check(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, t.want)
+	}
+
+	_, err := Build(Texts{Level: 3})
+	c.Check(err, check.ErrorMatches, "external: error calling MarshalText for type external.level: invalid level")
+	_, err = Build(Texts{Levels: []level{4}})
+	c.Check(err, check.ErrorMatches, "external: error calling MarshalText for type external.level: invalid level")
+
+	k, err := kv("-k", low)
+	c.Check(err, check.Equals, nil)
+	c.Check(k, check.Equals, "-k=1")
+	j, err := join(",", map[string]level{"a": high})
+	c.Check(err, check.Equals, nil)
+	c.Check(j, check.Equals, "2")
+	_, err = join(",", []level{5})
+	c.Check(err, check.ErrorMatches, "external: error calling MarshalText for type external.level: invalid level")
+}
@@ -27,62 +27,71 @@ var (
 type MakeUniverse struct {
 	// Usage: kmeans makeuni in <infile>
 	//
-	Cmd string `buildarg:"{{if .}}{{.}}{{else}}kmeans{{end}}"` // kmeans
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}kmeans{{end}}" doc:"kmeans"`
 
 	// Make Universe:
-	Kmeans struct{} `buildarg:"makeuni"` // makeuni
+	Kmeans struct{} `buildarg:"makeuni" doc:"makeuni"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
 
 	// Files:
-	Infile string `buildarg:"{{if .}}in{{split}}{{.}}{{end}}"` // in <file>
+	Infile string `buildarg:"{{if .}}in{{split}}{{.}}{{end}}" path:"in" doc:"in <file>"`
 }
 
 func (u MakeUniverse) BuildCommand() (*exec.Cmd, error) {
 	if u.Infile == "" {
 		return nil, ErrMissingRequired
 	}
-	cl := external.Must(external.Build(u))
-	return exec.Command(cl[0], cl[1:]...), nil
+	cl, err := external.Build(u)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
 }
 
 type Xmeans struct {
 	// Usage: kmeans kmeans [options] -in <infile>
 	//
-	Cmd string `buildarg:"{{if .}}{{.}}{{else}}kmeans{{end}}"` // kmeans
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}kmeans{{end}}" doc:"kmeans"`
 
 	// Kmeans:
-	Kmeans struct{} `buildarg:"kmeans"` // kmeans
+	Kmeans struct{} `buildarg:"kmeans" doc:"kmeans"`
 
 	// Files:
-	InFile           string `buildarg:"{{if .}}-in{{split}}{{.}}{{end}}"`                     // -in <file>
-	Constraints      string `buildarg:"{{if .}}-cons{{split}}{{.}}{{end}}"`                   // -cons <file>
-	InitCenters      string `buildarg:"{{if .}}-init_ctrs{{split}}{{.}}{{end}}"`              // -init_ctrs <file>
-	SaveCenters      string `buildarg:"{{if .}}-save_ctrs{{split}}{{.}}{{end}}"`              // -save_ctrs <file>
-	PrintClusters    string `buildarg:"{{if .}}-printclusters{{split}}{{.}}{{end}}"`          // -printclusters <file>
-	PrintNonClusters string `buildarg:"{{if .}}-print_no_cons_clusters{{split}}{{.}}{{end}}"` // -print_no_cons_clusters <file>
+	InFile           string `buildarg:"{{if .}}-in{{split}}{{.}}{{end}}" path:"in" doc:"-in <file>"`
+	Constraints      string `buildarg:"{{if .}}-cons{{split}}{{.}}{{end}}" path:"in" doc:"-cons <file>"`
+	InitCenters      string `buildarg:"{{if .}}-init_ctrs{{split}}{{.}}{{end}}" path:"in" doc:"-init_ctrs <file>"`
+	SaveCenters      string `buildarg:"{{if .}}-save_ctrs{{split}}{{.}}{{end}}" path:"out" doc:"-save_ctrs <file>"`
+	PrintClusters    string `buildarg:"{{if .}}-printclusters{{split}}{{.}}{{end}}" path:"out" doc:"-printclusters <file>"`
+	PrintNonClusters string `buildarg:"{{if .}}-print_no_cons_clusters{{split}}{{.}}{{end}}" path:"out" requires:"Constraints" doc:"-print_no_cons_clusters <file>"`
 
 	// Options:
-	InitialK         int     `buildarg:"{{if .}}-k{{split}}{{.}}{{end}}"`                     // -k <int>
-	MaxCenters       int     `buildarg:"{{if .}}-max_ctrs{{split}}{{.}}{{end}}"`              // -max_ctrs <int>
-	Method           string  `buildarg:"{{if .}}-method{{split}}{{.}}{{end}}"`                // -method <string>
-	Splits           int     `buildarg:"{{if .}}-num_splits{{split}}{{.}}{{end}}"`            // -num_splits <int>
-	DelSteps         int     `buildarg:"{{if .}}-del_steps_ratio{{split}}{{.}}{{end}}"`       // -del_steps_ratio <int>
-	MaxIterations    int     `buildarg:"{{if .}}-max_iter{{split}}{{.}}{{end}}"`              // -max_iter <int>
-	CutoffFactor     float64 `buildarg:"{{if .}}-cutoff_factor{{split}}{{.}}{{end}}"`         // -cutoff_factor <float>
-	MaxLeafSize      int     `buildarg:"{{if .}}-max_leaf_size{{split}}{{.}}{{end}}"`         // -max_leaf_size <int>
-	MinBoxWidth      float64 `buildarg:"{{if .}}-min_box_width{{split}}{{.}}{{end}}"`         // -min_box_width <float>
-	CreateUniverse   bool    `buildarg:"{{if .}}-create_universe{{split}}true{{end}}"`        // -create_universe <bool>
-	NeverKillCenters bool    `buildarg:"{{if .}}-never_kill_ctrs{{split}}true{{end}}"`        // -never_kill_ctrs <bool>
-	SplitStatistic   string  `buildarg:"{{if .}}-S{{split}}{{.}}{{end}}"`                     // -S <string>
-	Seed             int     `buildarg:"{{if .}}-seed{{split}}{{.}}{{end}}"`                  // -seed <int>
-	RandStart        bool    `buildarg:"{{if .}}-randstart{{split}}true{{end}}"`              // -randstart <bool>
-	ForceSplitFrac   float64 `buildarg:"{{if .}}-forced_split_fraction{{split}}{{.}}{{end}}"` // -forced_split_fraction <float>
-	SplitConfLevel   float64 `buildarg:"{{if .}}-split_conf_level{{split}}{{.}}{{end}}"`      // -split_conf_level <float>
+	InitialK         int     `buildarg:"{{if .}}-k{{split}}{{.}}{{end}}" doc:"-k <int>"`
+	MaxCenters       int     `buildarg:"{{if .}}-max_ctrs{{split}}{{.}}{{end}}" doc:"-max_ctrs <int>"`
+	Method           string  `buildarg:"{{if .}}-method{{split}}{{.}}{{end}}" doc:"-method <string>"`
+	Splits           int     `buildarg:"{{if .}}-num_splits{{split}}{{.}}{{end}}" doc:"-num_splits <int>"`
+	DelSteps         int     `buildarg:"{{if .}}-del_steps_ratio{{split}}{{.}}{{end}}" doc:"-del_steps_ratio <int>"`
+	MaxIterations    int     `buildarg:"{{if .}}-max_iter{{split}}{{.}}{{end}}" doc:"-max_iter <int>"`
+	CutoffFactor     float64 `buildarg:"{{if .}}-cutoff_factor{{split}}{{.}}{{end}}" doc:"-cutoff_factor <float>"`
+	MaxLeafSize      int     `buildarg:"{{if .}}-max_leaf_size{{split}}{{.}}{{end}}" doc:"-max_leaf_size <int>"`
+	MinBoxWidth      float64 `buildarg:"{{if .}}-min_box_width{{split}}{{.}}{{end}}" doc:"-min_box_width <float>"`
+	CreateUniverse   bool    `buildarg:"{{if .}}-create_universe{{split}}true{{end}}" doc:"-create_universe <bool>"`
+	NeverKillCenters bool    `buildarg:"{{if .}}-never_kill_ctrs{{split}}true{{end}}" doc:"-never_kill_ctrs <bool>"`
+	SplitStatistic   string  `buildarg:"{{if .}}-S{{split}}{{.}}{{end}}" doc:"-S <string>"`
+	Seed             int     `buildarg:"{{if .}}-seed{{split}}{{.}}{{end}}" doc:"-seed <int>"`
+	RandStart        bool    `buildarg:"{{if .}}-randstart{{split}}true{{end}}" doc:"-randstart <bool>"`
+	ForceSplitFrac   float64 `buildarg:"{{if .}}-forced_split_fraction{{split}}{{.}}{{end}}" doc:"-forced_split_fraction <float>"`
+	SplitConfLevel   float64 `buildarg:"{{if .}}-split_conf_level{{split}}{{.}}{{end}}" doc:"-split_conf_level <float>"`
 
 	// Display options:
-	ShowEndCenters bool `buildarg:"{{if .}}-D_SHOW_END_CENTERS{{end}}"` // -D_SHOW_END_CENTERS
-	DrawPoints     bool `buildarg:"{{if .}}-D_DRAWPOINTS{{end}}"`       // -D_DRAWPOINTS
-	Interactive    bool `buildarg:"{{if .}}-D_INTERACTIVE{{end}}"`      // -D_INTERACTIVE
-	ShowBValue     bool `buildarg:"{{if .}}-D_SHOW_BVALUE{{end}}"`      // -D_SHOW_BVALUE
+	ShowEndCenters bool `buildarg:"{{if .}}-D_SHOW_END_CENTERS{{end}}" doc:"-D_SHOW_END_CENTERS"`
+	DrawPoints     bool `buildarg:"{{if .}}-D_DRAWPOINTS{{end}}" doc:"-D_DRAWPOINTS"`
+	Interactive    bool `buildarg:"{{if .}}-D_INTERACTIVE{{end}}" doc:"-D_INTERACTIVE"`
+	ShowBValue     bool `buildarg:"{{if .}}-D_SHOW_BVALUE{{end}}" doc:"-D_SHOW_BVALUE"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
 }
 
 func (x Xmeans) BuildCommand() (*exec.Cmd, error) {
@@ -104,8 +113,11 @@ func (x Xmeans) BuildCommand() (*exec.Cmd, error) {
 		}
 	}
 
-	cl := external.Must(external.Build(x))
-	return exec.Command(cl[0], cl[1:]...), nil
+	cl, err := external.Build(x)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
 }
 
 func Membership(r io.Reader) ([]int, error) {
@@ -0,0 +1,376 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/biogo/external"
+)
+
+// ConvertFormat is an output format of maf-convert.
+type ConvertFormat string
+
+const (
+	ToAXT      ConvertFormat = "axt"
+	ToBAM      ConvertFormat = "bam"
+	ToBED      ConvertFormat = "bed"
+	ToBlast    ConvertFormat = "blast"
+	ToBlastTab ConvertFormat = "blasttab"
+	ToChain    ConvertFormat = "chain"
+	ToGFF      ConvertFormat = "gff"
+	ToHTML     ConvertFormat = "html"
+	ToPSL      ConvertFormat = "psl"
+	ToSAM      ConvertFormat = "sam"
+	ToTab      ConvertFormat = "tab"
+)
+
+var convertFormats = map[ConvertFormat]bool{
+	ToAXT: true, ToBAM: true, ToBED: true, ToBlast: true, ToBlastTab: true, ToChain: true,
+	ToGFF: true, ToHTML: true, ToPSL: true, ToSAM: true, ToTab: true,
+}
+
+// Convert is a builder for maf-convert. With no InFiles, maf-convert reads MAF from its
+// standard input. The converted alignments are written to standard output.
+//
+// The PSLWriter and SAMWriter types convert MAF blocks to PSL and SAM without running
+// maf-convert.
+type Convert struct {
+	// Usage: maf-convert [options] FORMAT input.maf
+	// Read MAF-format alignments & write them in another format.
+	//
+	// Options (default settings):
+	//  -p: assume protein alignments, for psl match counts
+	//  -j: join co-linear alignments separated by <= N letters
+	//  -n: omit any header lines from the output
+	//  -d: include dictionary of sequence lengths in sam format
+	//  -f: get sequence dictionary from DICTFILE
+	//  -r: read group info for sam format
+	//  -l: line length for blast and html formats (60)
+	//
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}maf-convert{{end}}" doc:"maf-convert"`
+
+	// Options:
+	Protein    bool   `flag:"-p" doc:"assume protein alignments"`
+	Join       int    `opt:"-j" doc:"join co-linear alignments separated by <= N letters"`
+	NoHeader   bool   `flag:"-n" doc:"omit any header lines"`
+	Dictionary bool   `flag:"-d" doc:"include dictionary of sequence lengths"`
+	DictFile   string `opt:"-f" path:"in" doc:"get sequence dictionary from file"`
+	ReadGroup  string `opt:"-r" doc:"read group info for sam format"`
+	LineSize   int    `opt:"-l" doc:"line length for blast and html formats"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
+
+	// Format and files:
+	Format  ConvertFormat `pos:"" doc:"FORMAT"`
+	InFiles []string      `pos:"" path:"in" doc:"<in.maf>..."`
+}
+
+func (c Convert) BuildCommand() (*exec.Cmd, error) {
+	if c.Format == "" {
+		return nil, ErrMissingRequired
+	}
+	if !convertFormats[c.Format] {
+		return nil, fmt.Errorf("last: unknown maf-convert format %q", string(c.Format))
+	}
+	cl, err := external.Build(c)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
+}
+
+// pair returns the reference and query rows of a pairwise alignment block.
+func (b Block) pair() (ref, query Row, err error) {
+	if len(b.Rows) != 2 {
+		return Row{}, Row{}, fmt.Errorf("last: cannot convert block with %d rows", len(b.Rows))
+	}
+	ref, query = b.Rows[0], b.Rows[1]
+	if ref.Strand != '+' {
+		return Row{}, Row{}, fmt.Errorf("last: cannot convert block with reference %s on - strand", ref.Name)
+	}
+	if len(ref.Text) != len(query.Text) {
+		return Row{}, Row{}, errors.New("last: aligned rows differ in length")
+	}
+	if strings.ContainsAny(ref.Text+query.Text, `\/`) {
+		return Row{}, Row{}, errors.New("last: cannot convert frameshifted alignment")
+	}
+	return ref, query, nil
+}
+
+// PSLWriter writes pairwise MAF alignment blocks as PSL lines in the form written by
+// maf-convert psl. The first row of each block is the PSL target and the second is the
+// PSL query. The repMatches and nCount columns are always zero.
+type PSLWriter struct {
+	w *bufio.Writer
+}
+
+// NewPSLWriter returns a PSLWriter that writes to w. The Flush method must be called
+// after the last block is written.
+func NewPSLWriter(w io.Writer) *PSLWriter {
+	return &PSLWriter{w: bufio.NewWriter(w)}
+}
+
+// Write writes b as a PSL line.
+func (w *PSLWriter) Write(b Block) error {
+	ref, query, err := b.pair()
+	if err != nil {
+		return err
+	}
+
+	var (
+		matches, mismatches int
+
+		sizes, qStarts, tStarts []int
+
+		// Gap runs and letters in
+		// the query and the target.
+		qNumInsert, qBaseInsert int
+		tNumInsert, tBaseInsert int
+	)
+	tPos, qPos := ref.Start, query.Start
+	var size, prev int // prev is 1 for a query insert, 2 for a target insert.
+	flush := func() {
+		if size != 0 {
+			sizes = append(sizes, size)
+			tStarts = append(tStarts, tPos-size)
+			qStarts = append(qStarts, qPos-size)
+			size = 0
+		}
+	}
+	for i := 0; i < len(ref.Text); i++ {
+		x, y := ref.Text[i], query.Text[i]
+		switch {
+		case x == '-' && y == '-':
+			continue
+		case x == '-':
+			flush()
+			if prev != 1 && len(sizes) != 0 {
+				qNumInsert++
+			}
+			qBaseInsert++
+			qPos++
+			prev = 1
+			continue
+		case y == '-':
+			flush()
+			if prev != 2 && len(sizes) != 0 {
+				tNumInsert++
+			}
+			tBaseInsert++
+			tPos++
+			prev = 2
+			continue
+		}
+		if upper(x) == upper(y) {
+			matches++
+		} else {
+			mismatches++
+		}
+		size++
+		tPos++
+		qPos++
+		prev = 0
+	}
+	flush()
+
+	qStart, qEnd := query.Start, query.End()
+	if query.Strand == '-' {
+		qStart, qEnd = query.SrcSize-qEnd, query.SrcSize-qStart
+	}
+	fmt.Fprintf(w.w, "%d\t%d\t0\t0\t%d\t%d\t%d\t%d\t%c\t%s\t%d\t%d\t%d\t%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\n",
+		matches, mismatches,
+		qNumInsert, qBaseInsert, tNumInsert, tBaseInsert,
+		query.Strand,
+		query.Name, query.SrcSize, qStart, qEnd,
+		ref.Name, ref.SrcSize, ref.Start, ref.End(),
+		len(sizes), commaList(sizes), commaList(qStarts), commaList(tStarts),
+	)
+	return nil
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *PSLWriter) Flush() error { return w.w.Flush() }
+
+func commaList(v []int) string {
+	var buf strings.Builder
+	for _, n := range v {
+		buf.WriteString(strconv.Itoa(n))
+		buf.WriteByte(',')
+	}
+	return buf.String()
+}
+
+func upper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// SAMWriter writes pairwise MAF alignment blocks as SAM records in the form written
+// by maf-convert sam. The first row of each block is the reference and the second is
+// the read. Unaligned ends of reads are hard clipped, and the mapping quality is given
+// by the mismap attribute written by last-split, or is 255. Records carry the NM edit
+// distance and AS score tags, and an EV tag holding the E attribute when it is present.
+type SAMWriter struct {
+	w *bufio.Writer
+
+	// ReadGroup is the read group header
+	// fields separated by spaces or tabs, as
+	// in the ReadGroup field of Convert, for
+	// example "ID:1 SM:sample", or empty.
+	ReadGroup string
+}
+
+// NewSAMWriter returns a SAMWriter that writes to w. The Flush method must be called
+// after the last block is written.
+func NewSAMWriter(w io.Writer) *SAMWriter {
+	return &SAMWriter{w: bufio.NewWriter(w)}
+}
+
+// WriteHeader writes the SAM header. The Name and SrcSize of each row in refs give
+// the @SQ lines of the sequence dictionary. Rows naming a sequence already given are
+// skipped.
+func (w *SAMWriter) WriteHeader(refs []Row) error {
+	fmt.Fprint(w.w, "@HD\tVN:1.3\tSO:unsorted\n")
+	seen := make(map[string]bool)
+	for _, r := range refs {
+		if seen[r.Name] {
+			continue
+		}
+		seen[r.Name] = true
+		fmt.Fprintf(w.w, "@SQ\tSN:%s\tLN:%d\n", r.Name, r.SrcSize)
+	}
+	if w.ReadGroup != "" {
+		fmt.Fprintf(w.w, "@RG\t%s\n", strings.Join(strings.Fields(w.ReadGroup), "\t"))
+	}
+	return nil
+}
+
+// readGroupID returns the ID of w.ReadGroup.
+func (w *SAMWriter) readGroupID() string {
+	for _, f := range strings.Fields(w.ReadGroup) {
+		if strings.HasPrefix(f, "ID:") {
+			return f[len("ID:"):]
+		}
+	}
+	return ""
+}
+
+// Write writes b as a SAM record.
+func (w *SAMWriter) Write(b Block) error {
+	ref, query, err := b.pair()
+	if err != nil {
+		return err
+	}
+
+	var flag int
+	if query.Strand == '-' {
+		flag |= 16
+	}
+
+	mapq := 255
+	if v, ok := b.Attr("mismap"); ok {
+		p, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("last: invalid mismap %q", v)
+		}
+		mapq = mapQuality(p)
+	}
+
+	var (
+		cigar strings.Builder
+		op    byte
+		n     int
+
+		// Mismatched and gapped columns.
+		edits int
+	)
+	emit := func(o byte, c int) {
+		if c != 0 {
+			cigar.WriteString(strconv.Itoa(c))
+			cigar.WriteByte(o)
+		}
+	}
+	emit('H', query.Start)
+	for i := 0; i < len(ref.Text); i++ {
+		var o byte
+		switch x, y := ref.Text[i], query.Text[i]; {
+		case x == '-' && y == '-':
+			continue
+		case x == '-':
+			o = 'I'
+		case y == '-':
+			o = 'D'
+		default:
+			o = 'M'
+		}
+		if o != 'M' || upper(ref.Text[i]) != upper(query.Text[i]) {
+			edits++
+		}
+		if o != op {
+			emit(op, n)
+			op, n = o, 0
+		}
+		n++
+	}
+	emit(op, n)
+	emit('H', query.SrcSize-query.End())
+
+	seq := strings.Replace(query.Text, "-", "", -1)
+	qual := "*"
+	if query.Quality != "" {
+		if len(query.Quality) != len(query.Text) {
+			return fmt.Errorf("last: quality length does not match alignment for %s", query.Name)
+		}
+		var q strings.Builder
+		for i := 0; i < len(query.Text); i++ {
+			if query.Text[i] != '-' {
+				q.WriteByte(query.Quality[i])
+			}
+		}
+		qual = q.String()
+	}
+
+	fmt.Fprintf(w.w, "%s\t%d\t%s\t%d\t%d\t%s\t*\t0\t0\t%s\t%s\tNM:i:%d\tAS:i:%s",
+		query.Name, flag, ref.Name, ref.Start+1, mapq, cigar.String(), seq, qual,
+		edits, strconv.FormatFloat(b.Score, 'f', -1, 64),
+	)
+	if e, ok := b.Attr("E"); ok {
+		fmt.Fprintf(w.w, "\tEV:Z:%s", e)
+	}
+	if id := w.readGroupID(); id != "" {
+		fmt.Fprintf(w.w, "\tRG:Z:%s", id)
+	}
+	_, err = w.w.WriteString("\n")
+	return err
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *SAMWriter) Flush() error { return w.w.Flush() }
+
+// mapQuality returns the SAM mapping quality for the mismap probability p.
+func mapQuality(p float64) int {
+	if p <= 0 {
+		return 254
+	}
+	q := math.Round(-10 * math.Log10(p))
+	switch {
+	case q < 0:
+		return 0
+	case q > 254:
+		return 254
+	}
+	return int(q)
+}
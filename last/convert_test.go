@@ -0,0 +1,94 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *P) TestConvertArgs(c *check.C) {
+	args, err := external.Build(Convert{NoHeader: true, ReadGroup: "ID:1", Format: ToSAM, InFiles: []string{"a.maf"}})
+	c.Check(err, check.Equals, nil)
+	c.Check(args, check.DeepEquals, []string{"maf-convert", "-n", "-r", "ID:1", "sam", "a.maf"})
+
+	_, err = Convert{}.BuildCommand()
+	c.Check(err, check.Equals, ErrMissingRequired)
+	_, err = Convert{Format: "fasta"}.BuildCommand()
+	c.Check(err, check.ErrorMatches, `last: unknown maf-convert format "fasta"`)
+}
+
+// convertMAF returns the blocks of testdata/align.maf converted by a PSLWriter or
+// SAMWriter.
+func convertMAF(c *check.C, format ConvertFormat) []byte {
+	var (
+		buf   bytes.Buffer
+		write func(Block) error
+		flush func() error
+	)
+	switch format {
+	case ToPSL:
+		w := NewPSLWriter(&buf)
+		write, flush = w.Write, w.Flush
+	case ToSAM:
+		w := NewSAMWriter(&buf)
+		write, flush = w.Write, w.Flush
+	}
+	for _, b := range readMAF(c, filepath.Join("testdata", "align.maf")) {
+		c.Assert(write(b), check.Equals, nil)
+	}
+	c.Assert(flush(), check.Equals, nil)
+	return buf.Bytes()
+}
+
+func (s *P) TestConvertWriters(c *check.C) {
+	for _, format := range []ConvertFormat{ToPSL, ToSAM} {
+		want, err := ioutil.ReadFile(filepath.Join("testdata", "align."+string(format)))
+		c.Assert(err, check.Equals, nil)
+		c.Check(string(convertMAF(c, format)), check.Equals, string(want), check.Commentf("format %s", format))
+	}
+
+	var buf bytes.Buffer
+	w := NewSAMWriter(&buf)
+	w.ReadGroup = "ID:rg1 SM:sample"
+	b := Block{
+		Score: 30,
+		Attrs: []Attr{{Key: "mismap", Value: "1e-05"}},
+		Rows: []Row{
+			{Name: "chr1", Start: 0, Size: 4, Strand: '+', SrcSize: 10, Text: "ACGT"},
+			{Name: "r", Start: 0, Size: 4, Strand: '+', SrcSize: 4, Text: "ACGA"},
+		},
+	}
+	c.Check(w.WriteHeader(b.Rows[:1]), check.Equals, nil)
+	c.Check(w.Write(b), check.Equals, nil)
+	c.Check(w.Flush(), check.Equals, nil)
+	c.Check(buf.String(), check.Equals, "@HD\tVN:1.3\tSO:unsorted\n@SQ\tSN:chr1\tLN:10\n@RG\tID:rg1\tSM:sample\n"+
+		"r\t0\tchr1\t1\t50\t4M\t*\t0\t0\tACGA\t*\tNM:i:1\tAS:i:30\tRG:Z:rg1\n")
+
+	b.Rows = b.Rows[:1]
+	c.Check(w.Write(b), check.ErrorMatches, "last: cannot convert block with 1 rows")
+	b.Rows = []Row{{Name: "chr1", Strand: '-', Text: "A"}, {Name: "r", Strand: '+', Text: "A"}}
+	c.Check(NewPSLWriter(&buf).Write(b), check.ErrorMatches, "last: cannot convert block with reference chr1 on - strand")
+}
+
+func (s *S) TestConvert(c *check.C) {
+	_, err := exec.LookPath("maf-convert")
+	if err != nil {
+		c.Skip("maf-convert not present")
+	}
+	for _, format := range []ConvertFormat{ToPSL, ToSAM} {
+		cmd, err := Convert{NoHeader: true, Format: format, InFiles: []string{filepath.Join("testdata", "align.maf")}}.BuildCommand()
+		c.Assert(err, check.Equals, nil)
+		out, err := cmd.Output()
+		c.Assert(err, check.Equals, nil)
+		c.Check(string(convertMAF(c, format)), check.Equals, string(out), check.Commentf("format %s", format))
+	}
+}
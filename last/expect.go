@@ -0,0 +1,302 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExpectMode is the set of reference/query combinations for which lastex calculates
+// expected numbers of alignments, the -z option and Calculate field of Expect.
+type ExpectMode int
+
+const (
+	WholeFiles ExpectMode = iota // 0: reference counts file / query counts file
+	PerQuery                     // 1: reference counts file / each query sequence
+	PerRef                       // 2: each reference sequence / query counts file
+	PerPair                      // 3: each reference sequence / each query sequence
+)
+
+// names returns the number of sequence names that precede the score on a line of
+// lastex output for m.
+func (m ExpectMode) names() int {
+	switch m {
+	case WholeFiles:
+		return 0
+	case PerQuery, PerRef:
+		return 1
+	case PerPair:
+		return 2
+	}
+	return -1
+}
+
+// ExpectCount is the expected number of alignments with at least a given score.
+type ExpectCount struct {
+	Score    int
+	Expected float64
+}
+
+// Expectation holds the expected numbers of alignments of one reference/query
+// combination.
+type Expectation struct {
+	// Ref and Query are the names of the
+	// reference and query sequences, or empty
+	// when the combination is for the whole
+	// counts file.
+	Ref   string
+	Query string
+
+	// Counts holds the expected counts in
+	// increasing order of score.
+	Counts []ExpectCount
+}
+
+// EValue returns the expected number of alignments with at least the given score and
+// whether it can be determined. Values between and beyond the scores held by e are
+// interpolated or extrapolated on a log scale from the nearest two scores. If e holds
+// only one score, only that score has a value.
+func (e *Expectation) EValue(score float64) (float64, bool) {
+	c := e.Counts
+	switch len(c) {
+	case 0:
+		return 0, false
+	case 1:
+		if float64(c[0].Score) != score {
+			return 0, false
+		}
+		return c[0].Expected, true
+	}
+	i := sort.Search(len(c), func(i int) bool { return float64(c[i].Score) > score }) - 1
+	switch {
+	case i < 0:
+		i = 0
+	case i >= len(c)-1:
+		if float64(c[len(c)-1].Score) == score {
+			return c[len(c)-1].Expected, true
+		}
+		i = len(c) - 2
+	}
+	lo, hi := c[i], c[i+1]
+	if float64(lo.Score) == score {
+		return lo.Expected, true
+	}
+	if lo.Expected <= 0 || hi.Expected <= 0 {
+		if score < float64(lo.Score) {
+			return 0, false
+		}
+		return lo.Expected, true
+	}
+	f := (score - float64(lo.Score)) / float64(hi.Score-lo.Score)
+	return math.Exp(math.Log(lo.Expected) + f*(math.Log(hi.Expected)-math.Log(lo.Expected))), true
+}
+
+// Expectations holds the expected numbers of alignments written by lastex.
+type Expectations struct {
+	// Mode is the set of combinations
+	// calculated by lastex.
+	Mode ExpectMode
+
+	// Lambda and K are the parameters given
+	// by comment lines, or zero if absent.
+	Lambda float64
+	K      float64
+
+	// Results holds the expectations for each
+	// combination in the order they were read.
+	Results []Expectation
+}
+
+// ReadOutput reads the output of the lastex command built by e from r.
+func (e Expect) ReadOutput(r io.Reader) (*Expectations, error) {
+	return ReadExpect(r, ExpectMode(e.Calculate))
+}
+
+// ReadExpect reads the output of lastex run with the -z option mode from r. Each line
+// of the output holds a score and the expected number of alignments with at least that
+// score, preceded by the name of the query sequence for PerQuery, the name of the
+// reference sequence for PerRef and the names of both for PerPair. Consecutive lines
+// with the same names form one Expectation. Comment lines are skipped except that any
+// lambda= and K= values they hold are recorded.
+func ReadExpect(r io.Reader, mode ExpectMode) (*Expectations, error) {
+	n := mode.names()
+	if n < 0 {
+		return nil, fmt.Errorf("last: invalid lastex mode %d", mode)
+	}
+	x := &Expectations{Mode: mode}
+	sc := bufio.NewScanner(r)
+	var line int
+	for sc.Scan() {
+		line++
+		l := strings.TrimSpace(sc.Text())
+		if l == "" {
+			continue
+		}
+		if l[0] == '#' {
+			err := x.parseComment(l[1:])
+			if err != nil {
+				return nil, fmt.Errorf("last: lastex line %d: %v", line, err)
+			}
+			continue
+		}
+		f := strings.Fields(l)
+		if len(f) != n+2 {
+			return nil, fmt.Errorf("last: lastex line %d: have %d fields, want %d", line, len(f), n+2)
+		}
+		var ref, query string
+		switch mode {
+		case PerQuery:
+			query = f[0]
+		case PerRef:
+			ref = f[0]
+		case PerPair:
+			ref, query = f[0], f[1]
+		}
+		score, err := strconv.Atoi(f[n])
+		if err != nil {
+			return nil, fmt.Errorf("last: lastex line %d: invalid score %q", line, f[n])
+		}
+		expected, err := strconv.ParseFloat(f[n+1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("last: lastex line %d: invalid expected count %q", line, f[n+1])
+		}
+		if k := len(x.Results); k == 0 || x.Results[k-1].Ref != ref || x.Results[k-1].Query != query {
+			x.Results = append(x.Results, Expectation{Ref: ref, Query: query})
+		}
+		e := &x.Results[len(x.Results)-1]
+		e.Counts = append(e.Counts, ExpectCount{Score: score, Expected: expected})
+	}
+	err := sc.Err()
+	if err != nil {
+		return nil, err
+	}
+	for i := range x.Results {
+		c := x.Results[i].Counts
+		sort.SliceStable(c, func(i, j int) bool { return c[i].Score < c[j].Score })
+	}
+	return x, nil
+}
+
+func (x *Expectations) parseComment(l string) error {
+	for _, kv := range strings.Fields(l) {
+		var dst *float64
+		switch {
+		case strings.HasPrefix(kv, "lambda="):
+			dst = &x.Lambda
+		case strings.HasPrefix(kv, "K="):
+			dst = &x.K
+		default:
+			continue
+		}
+		v := kv[strings.Index(kv, "=")+1:]
+		var err error
+		*dst, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value %q", kv)
+		}
+	}
+	return nil
+}
+
+// Find returns the expectation for alignments of the reference sequence ref to the
+// query sequence query, ignoring the names that are not part of the combinations of
+// x.Mode, and whether it was found.
+func (x *Expectations) Find(ref, query string) (*Expectation, bool) {
+	switch x.Mode {
+	case WholeFiles:
+		ref, query = "", ""
+	case PerQuery:
+		ref = ""
+	case PerRef:
+		query = ""
+	}
+	for i, e := range x.Results {
+		if e.Ref == ref && e.Query == query {
+			return &x.Results[i], true
+		}
+	}
+	return nil, false
+}
+
+// EValue returns the expected number of alignments with at least the score of the
+// pairwise alignment b. The first row of b is the reference and the second the query.
+func (x *Expectations) EValue(b Block) (float64, error) {
+	if len(b.Rows) != 2 {
+		return 0, fmt.Errorf("last: cannot find E-value for block with %d rows", len(b.Rows))
+	}
+	ref, query := b.Rows[0].Name, b.Rows[1].Name
+	e, ok := x.Find(ref, query)
+	if !ok {
+		return 0, fmt.Errorf("last: no expectation for %s and %s", ref, query)
+	}
+	v, ok := e.EValue(b.Score)
+	if !ok {
+		return 0, fmt.Errorf("last: no expectation for score %v of %s and %s", b.Score, ref, query)
+	}
+	return v, nil
+}
+
+// AddEValues copies the MAF alignment blocks read from src to dst, setting the "E"
+// attribute of each block to the E-value of its score given by x. Comment lines are
+// copied ahead of the block that follows them.
+func (x *Expectations) AddEValues(dst io.Writer, src io.Reader) error {
+	r := NewMAFReader(src)
+	w := NewMAFWriter(dst)
+	var comments int
+	writeComments := func() error {
+		for _, c := range r.Comments[comments:] {
+			err := w.WriteComment(c)
+			if err != nil {
+				return err
+			}
+		}
+		comments = len(r.Comments)
+		return nil
+	}
+	for {
+		b, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		err = writeComments()
+		if err != nil {
+			return err
+		}
+		e, err := x.EValue(b)
+		if err != nil {
+			return err
+		}
+		b.setAttr("E", strconv.FormatFloat(e, 'g', 2, 64))
+		err = w.Write(b)
+		if err != nil {
+			return err
+		}
+	}
+	err := writeComments()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// setAttr sets the value of the "a" line attribute key, adding it if it is absent.
+func (b *Block) setAttr(key, value string) {
+	for i, a := range b.Attrs {
+		if a.Key == key {
+			b.Attrs[i].Value = value
+			return
+		}
+	}
+	b.Attrs = append(b.Attrs, Attr{Key: key, Value: value})
+}
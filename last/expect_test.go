@@ -0,0 +1,129 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *P) TestReadExpect(c *check.C) {
+	for _, t := range []struct {
+		mode ExpectMode
+		in   string
+		want []Expectation
+	}{
+		{
+			mode: WholeFiles,
+			in:   "# lambda=1.09602 K=0.335388\n40 0.5\n30 50\n",
+			want: []Expectation{{Counts: []ExpectCount{{30, 50}, {40, 0.5}}}},
+		},
+		{
+			mode: PerQuery,
+			in:   "read1 40 1e-04\nread1 42 1e-05\nread2 27 0.002\n",
+			want: []Expectation{
+				{Query: "read1", Counts: []ExpectCount{{40, 1e-04}, {42, 1e-05}}},
+				{Query: "read2", Counts: []ExpectCount{{27, 0.002}}},
+			},
+		},
+		{
+			mode: PerRef,
+			in:   "chr1\t35\t0.3\n",
+			want: []Expectation{{Ref: "chr1", Counts: []ExpectCount{{35, 0.3}}}},
+		},
+		{
+			mode: PerPair,
+			in:   "chr1 read1 35 0.3\nchr2 read1 35 0.06\n",
+			want: []Expectation{
+				{Ref: "chr1", Query: "read1", Counts: []ExpectCount{{35, 0.3}}},
+				{Ref: "chr2", Query: "read1", Counts: []ExpectCount{{35, 0.06}}},
+			},
+		},
+	} {
+		x, err := ReadExpect(strings.NewReader(t.in), t.mode)
+		c.Assert(err, check.Equals, nil)
+		c.Check(x.Mode, check.Equals, t.mode)
+		c.Check(x.Results, check.DeepEquals, t.want, check.Commentf("mode %d", t.mode))
+	}
+
+	x, err := Expect{}.ReadOutput(strings.NewReader("# lambda=1.09602 K=0.335388\n40 0.5\n"))
+	c.Assert(err, check.Equals, nil)
+	c.Check(x.Lambda, check.Equals, 1.09602)
+	c.Check(x.K, check.Equals, 0.335388)
+	e, ok := x.Find("chr1", "read1")
+	c.Check(ok, check.Equals, true)
+	c.Check(e.Counts, check.HasLen, 1)
+
+	for _, t := range []struct {
+		mode ExpectMode
+		in   string
+		err  string
+	}{
+		{WholeFiles, "read1 40 0.5\n", "last: lastex line 1: have 3 fields, want 2"},
+		{PerQuery, "read1 x 0.5\n", `last: lastex line 1: invalid score "x"`},
+		{PerQuery, "read1 40 y\n", `last: lastex line 1: invalid expected count "y"`},
+		{WholeFiles, "# lambda=z\n", `last: lastex line 1: invalid value "lambda=z"`},
+		{4, "", "last: invalid lastex mode 4"},
+	} {
+		_, err = ReadExpect(strings.NewReader(t.in), t.mode)
+		c.Check(err, check.ErrorMatches, t.err)
+	}
+}
+
+func (s *P) TestEValue(c *check.C) {
+	e := Expectation{Counts: []ExpectCount{{30, 1}, {40, 0.01}}}
+	for _, t := range []struct {
+		score float64
+		want  float64
+	}{
+		{30, 1},
+		{40, 0.01},
+		{35, 0.1},
+		{50, 1e-4},
+		{20, 100},
+	} {
+		v, ok := e.EValue(t.score)
+		c.Check(ok, check.Equals, true)
+		c.Check(math.Abs(v-t.want) < 1e-9*t.want, check.Equals, true, check.Commentf("score %v: got %v want %v", t.score, v, t.want))
+	}
+	e = Expectation{Counts: []ExpectCount{{30, 1}}}
+	_, ok := e.EValue(31)
+	c.Check(ok, check.Equals, false)
+}
+
+func (s *P) TestAddEValues(c *check.C) {
+	x, err := ReadExpect(strings.NewReader("read1 40 1e-04\nread1 42 1e-05\nread2 27 0.002\n"), PerQuery)
+	c.Assert(err, check.Equals, nil)
+
+	f, err := os.Open(filepath.Join("testdata", "align.maf"))
+	c.Assert(err, check.Equals, nil)
+	defer f.Close()
+	var buf bytes.Buffer
+	c.Assert(x.AddEValues(&buf, f), check.Equals, nil)
+
+	r := NewMAFReader(&buf)
+	for _, want := range []string{"3.2e-05", "0.002"} {
+		b, err := r.Read()
+		c.Assert(err, check.Equals, nil)
+		v, _ := b.Attr("E")
+		c.Check(v, check.Equals, want)
+		v, _ = b.Attr("EG2")
+		c.Check(v, check.Not(check.Equals), "")
+	}
+	_, err = r.Read()
+	c.Check(err, check.Equals, io.EOF)
+	c.Check(r.Comments[0], check.Equals, " LAST version 1179")
+	c.Check(r.Comments[len(r.Comments)-1], check.Equals, " Query sequences=2")
+
+	x.Mode = PerRef
+	f.Seek(0, 0)
+	c.Check(x.AddEValues(&bytes.Buffer{}, f), check.ErrorMatches, "last: no expectation for chr1 and read1")
+}
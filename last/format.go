@@ -0,0 +1,158 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/biogo/external"
+)
+
+// Format is an output format of lastal, the -f option.
+type Format string
+
+const (
+	MAF          Format = "MAF"       // MAF, the lastal default.
+	MAFPlus      Format = "MAF+"      // MAF with extra per-alignment information.
+	Tab          Format = "TAB"       // LAST tabular format.
+	BlastTab     Format = "BlastTab"  // BLAST tabular format.
+	BlastTabPlus Format = "BlastTab+" // BLAST tabular format with sequence lengths.
+)
+
+// check returns an error if f is not a known format.
+func (f Format) check() error {
+	switch f {
+	case MAF, MAFPlus, Tab, BlastTab, BlastTabPlus:
+		return nil
+	}
+	return fmt.Errorf("last: unknown output format %q", string(f))
+}
+
+// ErrVersion is returned when the version of a last tool cannot be determined.
+var ErrVersion = errors.New("last: cannot determine version")
+
+var (
+	versionLine = regexp.MustCompile(`^\S+\s+(\d+)`)
+
+	versionMu sync.Mutex
+	versions  = make(map[string]int)
+)
+
+// Version returns the version number of the last tool cmd, for example 1179 for
+// LAST 1179, by running it with the --version flag. The tool is resolved by
+// external.DefaultResolver and the result is cached for each resolved path.
+func Version(cmd string) (int, error) {
+	path, err := external.Resolve(cmd)
+	if err != nil {
+		return 0, err
+	}
+	versionMu.Lock()
+	v, ok := versions[path]
+	versionMu.Unlock()
+	if ok {
+		return v, nil
+	}
+
+	c, err := external.Command([]string{cmd, "--version"})
+	if err != nil {
+		return 0, err
+	}
+	out, err := c.Output()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", ErrVersion, cmd, err)
+	}
+	m := versionLine.FindSubmatch(bytes.TrimSpace(out))
+	if m == nil {
+		return 0, fmt.Errorf("%w: %s: unexpected output %q", ErrVersion, cmd, out)
+	}
+	v, err = strconv.Atoi(string(m[1]))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %v", ErrVersion, cmd, err)
+	}
+	versionMu.Lock()
+	versions[path] = v
+	versionMu.Unlock()
+	return v, nil
+}
+
+// checkFormat returns an error if a.Format is not a known format or conflicts with
+// a.Tabular.
+func (a Align) checkFormat() error {
+	if a.Format == "" {
+		return nil
+	}
+	if a.Tabular {
+		return errors.New("last: both Tabular and Format set")
+	}
+	return a.Format.check()
+}
+
+// Record is an alignment record read from lastal output. The dynamic type of a Record
+// is Block, TabRecord or BlastTabRecord.
+type Record interface {
+	record()
+}
+
+func (Block) record()          {}
+func (TabRecord) record()      {}
+func (BlastTabRecord) record() {}
+
+// RecordReader is a reader of alignment records.
+type RecordReader interface {
+	// ReadRecord returns the next record. At the
+	// end of the input, ReadRecord returns io.EOF.
+	ReadRecord() (Record, error)
+}
+
+// ReadRecord returns the next block as a Record.
+func (r *MAFReader) ReadRecord() (Record, error) {
+	b, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ReadRecord returns the next record as a Record.
+func (r *TabReader) ReadRecord() (Record, error) {
+	rec, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// ReadRecord returns the next record as a Record.
+func (r *BlastTabReader) ReadRecord() (Record, error) {
+	rec, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// NewRecordReader returns a RecordReader for the output of the lastal command built by
+// a read from r: a *MAFReader for MAF output, a *TabReader for tabular output and a
+// *BlastTabReader for BLAST tabular output.
+func (a Align) NewRecordReader(r io.Reader) (RecordReader, error) {
+	if a.Tabular {
+		return NewTabReader(r), nil
+	}
+	switch a.Format {
+	case "", MAF, MAFPlus:
+		return NewMAFReader(r), nil
+	case Tab:
+		return NewTabReader(r), nil
+	case BlastTab, BlastTabPlus:
+		return NewBlastTabReader(r), nil
+	}
+	return nil, a.Format.check()
+}
@@ -0,0 +1,104 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+// fakeTool registers a shell script as the binary for the tool name and returns a
+// function that removes the registration.
+func fakeTool(c *check.C, name, script string) func() {
+	path := filepath.Join(c.MkDir(), name)
+	err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755)
+	c.Assert(err, check.Equals, nil)
+	external.DefaultResolver.Register(name, path)
+	return func() { external.DefaultResolver.Register(name, "") }
+}
+
+// fakeLastal registers a script that reports version as the lastal binary and returns
+// a function that removes the registration.
+func fakeLastal(c *check.C, version string) func() {
+	return fakeTool(c, "lastal", "echo '"+version+"'\n")
+}
+
+func (s *P) TestVersion(c *check.C) {
+	defer fakeLastal(c, "lastal 1179")()
+	v, err := Version("lastal")
+	c.Check(err, check.Equals, nil)
+	c.Check(v, check.Equals, 1179)
+
+	defer fakeLastal(c, "unknown")()
+	_, err = Version("lastal")
+	c.Check(err, check.ErrorMatches, `last: cannot determine version: lastal: unexpected output "unknown\\n"`)
+}
+
+func (s *P) TestFormat(c *check.C) {
+	a := Align{DB: "db", InFiles: []string{"in.fa"}}
+	for _, t := range []struct {
+		format  Format
+		tabular bool
+		arg     []string
+		err     string
+	}{
+		{format: "", arg: nil},
+		{format: "", tabular: true, arg: []string{"-f", "0"}},
+		{format: BlastTab, arg: []string{"-f", "BlastTab"}},
+		{format: Tab, arg: []string{"-f", "TAB"}},
+		{format: BlastTabPlus, arg: []string{"-f", "BlastTab+"}},
+		{format: "SAM", err: `last: unknown output format "SAM"`},
+		{format: MAF, tabular: true, err: "last: both Tabular and Format set"},
+	} {
+		a.Format = t.format
+		a.Tabular = t.tabular
+		args, err := external.Build(a)
+		if t.err != "" {
+			_, err = a.BuildCommand()
+			c.Check(err, check.ErrorMatches, t.err)
+			continue
+		}
+		c.Assert(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, append(append([]string{"lastal"}, t.arg...), "db", "in.fa"))
+	}
+}
+
+func (s *P) TestNewRecordReader(c *check.C) {
+	for _, t := range []struct {
+		a    Align
+		file string
+		want Record
+	}{
+		{a: Align{}, file: "align.maf", want: Block{}},
+		{a: Align{Format: MAFPlus}, file: "align.maf", want: Block{}},
+		{a: Align{Tabular: true}, file: "align.tab", want: TabRecord{}},
+		{a: Align{Format: Tab}, file: "align.tab", want: TabRecord{}},
+		{a: Align{Format: BlastTabPlus}, file: "align.blasttab", want: BlastTabRecord{}},
+	} {
+		f, err := os.Open(filepath.Join("testdata", t.file))
+		c.Assert(err, check.Equals, nil)
+		r, err := t.a.NewRecordReader(f)
+		c.Assert(err, check.Equals, nil)
+		n := 0
+		for {
+			rec, err := r.ReadRecord()
+			if err != nil {
+				break
+			}
+			c.Check(rec, check.FitsTypeOf, t.want)
+			n++
+		}
+		c.Check(n, check.Equals, 2)
+		f.Close()
+	}
+	_, err := Align{Format: "SAM"}.NewRecordReader(strings.NewReader(""))
+	c.Check(err, check.ErrorMatches, `last: unknown output format "SAM"`)
+}
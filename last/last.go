@@ -33,34 +33,53 @@ type DB struct {
 	//  -b: bucket depth
 	//  -x: just count sequences and letters
 	//  -v: be verbose: write messages about what lastdb is doing
+	//  -P: number of parallel threads (1)
 	//
-	Cmd string `buildarg:"{{if .}}{{.}}{{else}}lastdb{{end}}"` // lastdb
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}lastdb{{end}}" doc:"lastdb"`
 
 	// Main Options:
-	Protein  bool `buildarg:"{{if .}}-p{{end}}"` // -p: interpret the sequences as proteins
-	Softmask bool `buildarg:"{{if .}}-c{{end}}"` // -c: soft-mask lowercase letters
+	Protein  bool `buildarg:"{{if .}}-p{{end}}" doc:"interpret the sequences as proteins"`
+	Softmask bool `buildarg:"{{if .}}-c{{end}}" doc:"soft-mask lowercase letters"`
 
 	// Advanced Options:
-	VolumeSize  int    `buildarg:"{{if .}}-s{{split}}{{.}}{{end}}"` // -s: volume size
-	SeedPattern string `buildarg:"{{if .}}-m{{split}}{{.}}{{end}}"` // -m: spaced seed pattern
-	HeaderFile  string `buildarg:"{{if .}}-u{{split}}{{.}}{{end}}"` // -u: subset seed file
-	IndexStep   int    `buildarg:"{{if .}}-w{{split}}{{.}}{{end}}"` // -w: index step
-	Alphabet    string `buildarg:"{{if .}}-a{{split}}{{.}}{{end}}"` // -a: user-defined alphabet
-	BucketDepth int    `buildarg:"{{if .}}-b{{split}}{{.}}{{end}}"` // -b: bucket depth
-	OnlyCount   bool   `buildarg:"{{if .}}-x{{end}}"`               // -x: just count sequences and letters
-	Verbose     bool   `buildarg:"{{if .}}-v{{end}}"`               // -v: be verbose
+	VolumeSize  int    `buildarg:"{{if .}}-s{{split}}{{.}}{{end}}" doc:"volume size"`
+	SeedPattern string `buildarg:"{{if .}}-m{{split}}{{.}}{{end}}" doc:"spaced seed pattern"`
+	HeaderFile  string `buildarg:"{{if .}}-u{{split}}{{.}}{{end}}" path:"in" doc:"subset seed file"`
+	IndexStep   int    `buildarg:"{{if .}}-w{{split}}{{.}}{{end}}" doc:"index step"`
+	Alphabet    string `buildarg:"{{if .}}-a{{split}}{{.}}{{end}}" doc:"user-defined alphabet"`
+	BucketDepth int    `buildarg:"{{if .}}-b{{split}}{{.}}{{end}}" doc:"bucket depth"`
+	OnlyCount   bool   `buildarg:"{{if .}}-x{{end}}" doc:"just count sequences and letters"`
+	Verbose     bool   `buildarg:"{{if .}}-v{{end}}" doc:"be verbose"`
+	Threads     int    `buildarg:"{{if .}}-P{{split}}{{.}}{{end}}" doc:"number of parallel threads"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
 
 	// Files:
-	OutFile string   `buildarg:"{{.}}"`      // "<lastdb>"
-	InFiles []string `buildarg:"{{args .}}"` // "<in.fa>"...
+	OutFile string   `buildarg:"{{.}}" path:"out" order:"1" doc:"<lastdb>"`
+	InFiles []string `buildarg:"{{args .}}" path:"in" order:"2" doc:"<in.fa>..."`
+}
+
+// Resources returns the resources required by the command built by db. The number
+// of CPUs is given by db.Threads if it is positive. No memory requirement is given
+// since the memory used by lastdb is not bounded by VolumeSize.
+func (db DB) Resources() external.Resources {
+	var r external.Resources
+	if db.Threads > 0 {
+		r.CPUs = db.Threads
+	}
+	return r
 }
 
 func (db DB) BuildCommand() (*exec.Cmd, error) {
 	if db.OutFile == "" || len(db.InFiles) == 0 {
 		return nil, ErrMissingRequired
 	}
-	cl := external.Must(external.Build(db))
-	return exec.Command(cl[0], cl[1:]...), nil
+	cl, err := external.Build(db)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
 }
 
 type Align struct {
@@ -86,7 +105,8 @@ type Align struct {
 	// Cosmetic options (default settings):
 	//  -v: be verbose: write messages about what lastal is doing
 	//  -o: output file
-	//  -f: output format: 0=tabular, 1=maf (1)
+	//  -f: output format: TAB, MAF, MAF+, BlastTab, BlastTab+ (MAF)
+	//      or, for older versions, 0=tabular, 1=maf (1)
 	//
 	// Miscellaneous options (default settings):
 	//  -s: strand: 0=reverse, 1=forward, 2=both (2 for DNA, 1 for protein)
@@ -95,6 +115,7 @@ type Align struct {
 	//  -n: maximum number of gapless alignments per query position (infinity)
 	//  -k: step-size along the query sequence (1)
 	//  -i: query batch size (8 KiB, unless there are multiple lastdb volumes)
+	//  -P: number of parallel threads (1)
 	//  -u: mask lowercase during extensions: 0=never, 1=gapless,
 	//     2=gapless+gapped but not final, 3=always (2 if lastdb -c and Q<5, else 0)
 	//  -w: supress repeats inside exact matches, offset by this distance or less (1000)
@@ -117,53 +138,92 @@ type Align struct {
 	//      4=prb,
 	//      5=PSSM (0)
 	//
-	Cmd string `buildarg:"{{if .}}{{.}}{{else}}lastal{{end}}"` // lastal
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}lastal{{end}}" doc:"lastal"`
 
 	// Score options:
-	MatchScore     int    `buildarg:"{{if .}}-r{{split}}{{.}}{{end}}"` // -r: match score
-	MismatchCost   int    `buildarg:"{{if .}}-q{{split}}{{.}}{{end}}"` // -q: mismatch cost
-	ScoreFile      string `buildarg:"{{if .}}-p{{split}}{{.}}{{end}}"` // -p: file for residue pair scores
-	GapCost        int    `buildarg:"{{if .}}-a{{split}}{{.}}{{end}}"` // -a: gap existence cost
-	ExtendCost     int    `buildarg:"{{if .}}-b{{split}}{{.}}{{end}}"` // -b: gap extension cost
-	UnalignedCost  int    `buildarg:"{{if .}}-c{{split}}{{.}}{{end}}"` // -c: unaligned residue pair cost
-	FrameShiftCost int    `buildarg:"{{if .}}-F{{split}}{{.}}{{end}}"` // -F: frameshift cost (off)
-	MaxGapDrop     int    `buildarg:"{{if .}}-x{{split}}{{.}}{{end}}"` // -x: max score drop for gapped
-	MaxGaplessDrop int    `buildarg:"{{if .}}-y{{split}}{{.}}{{end}}"` // -y: max score drop for gapless
-	MaxFinalDrop   int    `buildarg:"{{if .}}-z{{split}}{{.}}{{end}}"` // -z: max score drop for final gapped
-	MinGapless     int    `buildarg:"{{if .}}-d{{split}}{{.}}{{end}}"` // -d: min score for gapless
-	MinGapped      int    `buildarg:"{{if .}}-e{{split}}{{.}}{{end}}"` // -e: min score for gapped
+	MatchScore     int    `buildarg:"{{if .}}-r{{split}}{{.}}{{end}}" doc:"match score"`
+	MismatchCost   int    `buildarg:"{{if .}}-q{{split}}{{.}}{{end}}" doc:"mismatch cost"`
+	ScoreFile      string `buildarg:"{{if .}}-p{{split}}{{.}}{{end}}" path:"in" doc:"file for residue pair scores"`
+	GapCost        int    `buildarg:"{{if .}}-a{{split}}{{.}}{{end}}" doc:"gap existence cost"`
+	ExtendCost     int    `buildarg:"{{if .}}-b{{split}}{{.}}{{end}}" doc:"gap extension cost"`
+	InsertCost     int    `buildarg:"{{if .}}-A{{split}}{{.}}{{end}}" doc:"insertion existence cost"`
+	InsertExtend   int    `buildarg:"{{if .}}-B{{split}}{{.}}{{end}}" doc:"insertion extension cost"`
+	UnalignedCost  int    `buildarg:"{{if .}}-c{{split}}{{.}}{{end}}" doc:"unaligned residue pair cost"`
+	FrameShiftCost int    `buildarg:"{{if .}}-F{{split}}{{.}}{{end}}" doc:"frameshift cost (off)"`
+	MaxGapDrop     int    `buildarg:"{{if .}}-x{{split}}{{.}}{{end}}" doc:"max score drop for gapped"`
+	MaxGaplessDrop int    `buildarg:"{{if .}}-y{{split}}{{.}}{{end}}" doc:"max score drop for gapless"`
+	MaxFinalDrop   int    `buildarg:"{{if .}}-z{{split}}{{.}}{{end}}" doc:"max score drop for final gapped"`
+	MinGapless     int    `buildarg:"{{if .}}-d{{split}}{{.}}{{end}}" doc:"min score for gapless"`
+	MinGapped      int    `buildarg:"{{if .}}-e{{split}}{{.}}{{end}}" doc:"min score for gapped"`
 
 	// Cosmetic options:
-	Verbose bool   `buildarg:"{{if .}}-v{{end}}"`               // -v: be verbose
-	OutFile string `buildarg:"{{if .}}-o{{split}}{{.}}{{end}}"` // -o: output file
-	Tabular bool   `buildarg:"{{if .}}-f{{split}}0{{end}}"`     // -f: output format
+	Verbose bool   `buildarg:"{{if .}}-v{{end}}" doc:"be verbose"`
+	OutFile string `buildarg:"{{if .}}-o{{split}}{{.}}{{end}}" path:"out" doc:"output file"`
+	Tabular bool   `buildarg:"{{if .}}-f{{split}}0{{end}}" doc:"output format; deprecated, use Format"`
+	Format  Format `opt:"-f" doc:"output format"`
 
 	// Miscellaneous options:
-	Strand      int     `buildarg:"{{if .}}-s{{split}}{{.}}{{end}}"` // -s: strand
-	MaxMultiple int     `buildarg:"{{if .}}-m{{split}}{{.}}{{end}}"` // -m: max multiplicity for init matches
-	MinSeed     int     `buildarg:"{{if .}}-l{{split}}{{.}}{{end}}"` // -l: min length for init matches
-	MaxGapless  int     `buildarg:"{{if .}}-n{{split}}{{.}}{{end}}"` // -n: max number of gapless per query pos
-	StepSize    int     `buildarg:"{{if .}}-k{{split}}{{.}}{{end}}"` // -k: step-size along the query seq
-	BatchSize   int     `buildarg:"{{if .}}-i{{split}}{{.}}{{end}}"` // -i: query batch size
-	MaskLower   int     `buildarg:"{{if .}}-u{{split}}{{.}}{{end}}"` // -u: mask lowercase during extensions
-	SupressRep  int     `buildarg:"{{if .}}-w{{split}}{{.}}{{end}}"` // -w: supress repeats inside exact matches
-	GenCodeFile string  `buildarg:"{{if .}}-G{{split}}{{.}}{{end}}"` // -G: genetic code file
-	Temperature float64 `buildarg:"{{if .}}-t{{split}}{{.}}{{end}}"` // -t: 'temperature' for calculating probabilities
-	Gamma       float64 `buildarg:"{{if .}}-g{{split}}{{.}}{{end}}"` // -g: 'gamma' parameter for gamma-centroid and LAMA
-	OutputType  int     `buildarg:"{{if .}}-j{{split}}{{.}}{{end}}"` // -j: output type
-	InFormat    int     `buildarg:"{{if .}}-Q{{split}}{{.}}{{end}}"` // -Q: input format
+	Strand      int        `buildarg:"{{if .}}-s{{split}}{{.}}{{end}}" doc:"strand"`
+	MaxMultiple int        `buildarg:"{{if .}}-m{{split}}{{.}}{{end}}" doc:"max multiplicity for init matches"`
+	MinSeed     int        `buildarg:"{{if .}}-l{{split}}{{.}}{{end}}" doc:"min length for init matches"`
+	MaxGapless  int        `buildarg:"{{if .}}-n{{split}}{{.}}{{end}}" doc:"max number of gapless per query pos"`
+	StepSize    int        `buildarg:"{{if .}}-k{{split}}{{.}}{{end}}" doc:"step-size along the query seq"`
+	BatchSize   int        `buildarg:"{{if .}}-i{{split}}{{.}}{{end}}" doc:"query batch size"`
+	Threads     int        `buildarg:"{{if .}}-P{{split}}{{.}}{{end}}" doc:"number of parallel threads"`
+	MaskLower   MaskLower  `buildarg:"{{if .}}-u{{split}}{{.}}{{end}}" doc:"mask lowercase during extensions"`
+	SupressRep  int        `buildarg:"{{if .}}-w{{split}}{{.}}{{end}}" doc:"supress repeats inside exact matches"`
+	GenCodeFile string     `buildarg:"{{if .}}-G{{split}}{{.}}{{end}}" path:"in" doc:"genetic code file"`
+	Temperature float64    `buildarg:"{{if .}}-t{{split}}{{.}}{{end}}" doc:"'temperature' for calculating probabilities"`
+	Gamma       float64    `buildarg:"{{if .}}-g{{split}}{{.}}{{end}}" when:"OutputType=GammaCentroid|LAMA" doc:"'gamma' parameter for gamma-centroid and LAMA"`
+	OutputType  OutputType `buildarg:"{{if .}}-j{{split}}{{.}}{{end}}" doc:"output type"`
+	InFormat    InFormat   `buildarg:"{{if .}}-Q{{split}}{{.}}{{end}}" doc:"input format"`
+
+	// DBCheck specifies that BuildCommand reads the
+	// description of DB and checks the options
+	// against it with CheckDB. Not an argument.
+	DBCheck bool
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
 
 	// Files:
-	DB      string   `buildarg:"{{.}}"`      // "<lastdb>"
-	InFiles []string `buildarg:"{{args .}}"` // "<in.fa>"...
+	DB      string   `buildarg:"{{.}}" path:"in" order:"1" doc:"<lastdb>"`
+	InFiles []string `buildarg:"{{args .}}" path:"in" order:"2" doc:"<in.fa>..."`
+}
+
+// Resources returns the resources required by the command built by a. The number
+// of CPUs is given by a.Threads if it is positive.
+func (a Align) Resources() external.Resources {
+	var r external.Resources
+	if a.Threads > 0 {
+		r.CPUs = a.Threads
+	}
+	return r
 }
 
 func (a Align) BuildCommand() (*exec.Cmd, error) {
 	if a.DB == "" || len(a.InFiles) == 0 {
 		return nil, ErrMissingRequired
 	}
-	cl := external.Must(external.Build(a))
-	return exec.Command(cl[0], cl[1:]...), nil
+	err := a.checkFormat()
+	if err != nil {
+		return nil, err
+	}
+	if a.DBCheck {
+		info, err := ReadDBInfo(a.DB)
+		if err != nil {
+			return nil, err
+		}
+		err = a.CheckDB(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cl, err := external.Build(a)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
 }
 
 type Expect struct {
@@ -186,30 +246,36 @@ type Expect struct {
 	//      2 = each reference sequence / query counts file
 	//      3 = each reference sequence / each query sequence (0)
 	//
-	Cmd string `buildarg:"{{if .}}{{.}}{{else}}lastex{{end}}"` // lastex
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}lastex{{end}}" doc:"lastex"`
 
 	// Options:
-	Strand       int    `buildarg:"{{if .}}-s{{split}}{{.}}{{end}}"` // -s: strands
-	MatchScore   int    `buildarg:"{{if .}}-r{{split}}{{.}}{{end}}"` // -r: match score
-	MismatchCost int    `buildarg:"{{if .}}-q{{split}}{{.}}{{end}}"` // -q: mismatch cost
-	ScoreFile    string `buildarg:"{{if .}}-p{{split}}{{.}}{{end}}"` // -p: file for residue pair scores
-	GapCost      int    `buildarg:"{{if .}}-a{{split}}{{.}}{{end}}"` // -a: gap existence cost
-	ExtendCost   int    `buildarg:"{{if .}}-b{{split}}{{.}}{{end}}"` // -b: gap extension cost
-	DoGapless    bool   `buildarg:"{{if .}}-g{{end}}"`               // -g: do calculations for gapless
-	FindThresh   int    `buildarg:"{{if .}}-y{{split}}{{.}}{{end}}"` // -y: find alignments with score >= this
-	MaxExpected  int    `buildarg:"{{if .}}-E{{split}}{{.}}{{end}}"` // -E: maximum expected number
-	Calculate    int    `buildarg:"{{if .}}-z{{split}}{{.}}{{end}}"` // -z: calculate expected alignments
+	Strand       int    `buildarg:"{{if .}}-s{{split}}{{.}}{{end}}" doc:"strands"`
+	MatchScore   int    `buildarg:"{{if .}}-r{{split}}{{.}}{{end}}" doc:"match score"`
+	MismatchCost int    `buildarg:"{{if .}}-q{{split}}{{.}}{{end}}" doc:"mismatch cost"`
+	ScoreFile    string `buildarg:"{{if .}}-p{{split}}{{.}}{{end}}" path:"in" doc:"file for residue pair scores"`
+	GapCost      int    `buildarg:"{{if .}}-a{{split}}{{.}}{{end}}" doc:"gap existence cost"`
+	ExtendCost   int    `buildarg:"{{if .}}-b{{split}}{{.}}{{end}}" doc:"gap extension cost"`
+	DoGapless    bool   `buildarg:"{{if .}}-g{{end}}" doc:"do calculations for gapless"`
+	FindThresh   int    `buildarg:"{{if .}}-y{{split}}{{.}}{{end}}" doc:"find alignments with score >= this"`
+	MaxExpected  int    `buildarg:"{{if .}}-E{{split}}{{.}}{{end}}" doc:"maximum expected number"`
+	Calculate    int    `buildarg:"{{if .}}-z{{split}}{{.}}{{end}}" doc:"calculate expected alignments"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
 
 	// Files:
-	Ref        string   `buildarg:"{{.}}"`      // "<lastdb>"
-	Query      string   `buildarg:"{{.}}"`      // "<lastdb>"
-	AlignFiles []string `buildarg:"{{args .}}"` // "<in.maf>"...
+	Ref        string   `buildarg:"{{.}}" path:"in" order:"1" doc:"<lastdb>"`
+	Query      string   `buildarg:"{{.}}" path:"in" order:"2" doc:"<lastdb>"`
+	AlignFiles []string `buildarg:"{{args .}}" path:"in" order:"3" doc:"<in.maf>..."`
 }
 
 func (e Expect) BuildCommand() (*exec.Cmd, error) {
 	if e.Ref == "" || e.Query == "" {
 		return nil, ErrMissingRequired
 	}
-	cl := external.Must(external.Build(e))
-	return exec.Command(cl[0], cl[1:]...), nil
+	cl, err := external.Build(e)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
 }
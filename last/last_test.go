@@ -5,6 +5,8 @@
 package last
 
 import (
+	"bytes"
+	"flag"
 	"os/exec"
 	"testing"
 
@@ -52,3 +54,19 @@ func (s *S) TestBuild(c *check.C) {
 		c.Check(err, check.Equals, nil)
 	}
 }
+
+func (s *P) TestFlagUsage(c *check.C) {
+	fs := flag.NewFlagSet("lastal", flag.ContinueOnError)
+	err := external.BindFlags(fs, &Align{})
+	c.Assert(err, check.Equals, nil)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	for _, want := range []string{
+		"-match-score value\n    \tmatch score",
+		"-mismatch-cost value\n    \tmismatch cost",
+		"-db value\n    \t<lastdb>",
+	} {
+		c.Check(bytes.Contains(buf.Bytes(), []byte(want)), check.Equals, true, check.Commentf("missing %q in:\n%s", want, &buf))
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Attr is a key=value pair of a MAF alignment line.
+type Attr struct {
+	Key   string
+	Value string
+}
+
+// attr returns the value of the first of attrs with the given key and whether it is
+// present.
+func attr(attrs []Attr, key string) (string, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// Row is an aligned sequence of a MAF alignment block, given by an "s" line and
+// an optional "q" quality line.
+type Row struct {
+	// Name is the name of the sequence.
+	Name string
+
+	// Start is the zero-based start of the aligned
+	// region on the given strand and Size is the
+	// number of letters in the aligned region.
+	Start int
+	Size  int
+
+	// Strand is '+' or '-'.
+	Strand byte
+
+	// SrcSize is the length of the sequence.
+	SrcSize int
+
+	// Text is the aligned sequence, including gaps.
+	Text string
+
+	// Quality holds the aligned quality symbols of
+	// the sequence, or is empty.
+	Quality string
+}
+
+// End returns the end of the aligned region on the given strand.
+func (r Row) End() int { return r.Start + r.Size }
+
+// Block is a MAF alignment block.
+type Block struct {
+	// Score is the alignment score.
+	Score float64
+
+	// Attrs holds the key=value pairs of the "a"
+	// line other than the score, such as EG2 and E,
+	// in the order they were given.
+	Attrs []Attr
+
+	// Rows holds the aligned sequences.
+	Rows []Row
+
+	// Probs holds the column probability symbols
+	// of a "p" line, or is empty.
+	Probs string
+}
+
+// Attr returns the value of the "a" line attribute key and whether it is present.
+func (b Block) Attr(key string) (string, bool) {
+	return attr(b.Attrs, key)
+}
+
+// FloatAttr returns the value of the "a" line attribute key, such as E or EG2, as a
+// floating point number.
+func (b Block) FloatAttr(key string) (float64, error) {
+	v, ok := b.Attr(key)
+	if !ok {
+		return 0, fmt.Errorf("last: no %s attribute", key)
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// MAFReader reads MAF alignment blocks, such as those written by lastal.
+type MAFReader struct {
+	r    *bufio.Reader
+	line int
+
+	// Comments holds the text of the comment
+	// lines read so far, without the leading '#'.
+	Comments []string
+}
+
+// NewMAFReader returns a MAFReader that reads from r.
+func NewMAFReader(r io.Reader) *MAFReader {
+	return &MAFReader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next alignment block. At the end of the input, Read returns io.EOF.
+// Lines other than comments and "a", "s", "q" and "p" lines, such as the "i" and "e"
+// lines of other MAF writers, are ignored.
+func (r *MAFReader) Read() (Block, error) {
+	var (
+		b     Block
+		inBlk bool
+	)
+	for {
+		l, err := r.r.ReadString('\n')
+		if err != nil && (err != io.EOF || l == "") {
+			if err == io.EOF && inBlk {
+				return b, nil
+			}
+			return Block{}, err
+		}
+		r.line++
+		l = strings.TrimRight(l, "\r\n")
+
+		if l == "" || strings.TrimSpace(l) == "" {
+			if inBlk {
+				return b, nil
+			}
+			continue
+		}
+		if l[0] == '#' {
+			r.Comments = append(r.Comments, l[1:])
+			continue
+		}
+		f := strings.Fields(l)
+		switch f[0] {
+		case "a":
+			if inBlk {
+				return Block{}, r.errorf("unexpected a line in block")
+			}
+			inBlk = true
+			for _, kv := range f[1:] {
+				i := strings.Index(kv, "=")
+				if i < 0 {
+					return Block{}, r.errorf("invalid attribute %q", kv)
+				}
+				if kv[:i] == "score" {
+					b.Score, err = strconv.ParseFloat(kv[i+1:], 64)
+					if err != nil {
+						return Block{}, r.errorf("invalid score %q", kv[i+1:])
+					}
+					continue
+				}
+				b.Attrs = append(b.Attrs, Attr{Key: kv[:i], Value: kv[i+1:]})
+			}
+		case "s":
+			if !inBlk {
+				return Block{}, r.errorf("s line outside block")
+			}
+			row, err := r.parseRow(f)
+			if err != nil {
+				return Block{}, err
+			}
+			b.Rows = append(b.Rows, row)
+		case "q":
+			if len(f) != 3 {
+				return Block{}, r.errorf("invalid q line")
+			}
+			if !inBlk || len(b.Rows) == 0 || b.Rows[len(b.Rows)-1].Name != f[1] {
+				return Block{}, r.errorf("q line for %s does not follow its s line", f[1])
+			}
+			b.Rows[len(b.Rows)-1].Quality = f[2]
+		case "p":
+			if len(f) != 2 || !inBlk {
+				return Block{}, r.errorf("invalid p line")
+			}
+			b.Probs = f[1]
+		}
+	}
+}
+
+func (r *MAFReader) parseRow(f []string) (Row, error) {
+	if len(f) != 7 {
+		return Row{}, r.errorf("invalid s line")
+	}
+	var (
+		row Row
+		err error
+	)
+	row.Name = f[1]
+	row.Start, err = strconv.Atoi(f[2])
+	if err != nil {
+		return Row{}, r.errorf("invalid start %q", f[2])
+	}
+	row.Size, err = strconv.Atoi(f[3])
+	if err != nil {
+		return Row{}, r.errorf("invalid size %q", f[3])
+	}
+	if f[4] != "+" && f[4] != "-" {
+		return Row{}, r.errorf("invalid strand %q", f[4])
+	}
+	row.Strand = f[4][0]
+	row.SrcSize, err = strconv.Atoi(f[5])
+	if err != nil {
+		return Row{}, r.errorf("invalid source size %q", f[5])
+	}
+	row.Text = f[6]
+	return row, nil
+}
+
+func (r *MAFReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("last: maf line %d: %s", r.line, fmt.Sprintf(format, args...))
+}
+
+// MAFWriter writes MAF alignment blocks in the layout used by lastal.
+type MAFWriter struct {
+	w *bufio.Writer
+}
+
+// NewMAFWriter returns a MAFWriter that writes to w. The Flush method must be called
+// after the last block is written.
+func NewMAFWriter(w io.Writer) *MAFWriter {
+	return &MAFWriter{w: bufio.NewWriter(w)}
+}
+
+// WriteComment writes a comment line holding text.
+func (w *MAFWriter) WriteComment(text string) error {
+	_, err := fmt.Fprintf(w.w, "#%s\n", text)
+	return err
+}
+
+// Write writes b followed by a blank line.
+func (w *MAFWriter) Write(b Block) error {
+	fmt.Fprintf(w.w, "a score=%s", strconv.FormatFloat(b.Score, 'f', -1, 64))
+	for _, a := range b.Attrs {
+		fmt.Fprintf(w.w, " %s=%s", a.Key, a.Value)
+	}
+	w.w.WriteByte('\n')
+
+	var nameW, startW, sizeW, srcW int
+	for _, r := range b.Rows {
+		nameW = maxInt(nameW, len(r.Name))
+		startW = maxInt(startW, len(strconv.Itoa(r.Start)))
+		sizeW = maxInt(sizeW, len(strconv.Itoa(r.Size)))
+		srcW = maxInt(srcW, len(strconv.Itoa(r.SrcSize)))
+	}
+	// pad is the width of the text between the line
+	// type and the aligned text of an s line.
+	pad := nameW + 1 + startW + 1 + sizeW + 1 + 1 + 1 + srcW
+	for _, r := range b.Rows {
+		if r.Strand != '+' && r.Strand != '-' {
+			return fmt.Errorf("last: invalid strand %q for %s", r.Strand, r.Name)
+		}
+		fmt.Fprintf(w.w, "s %-*s %*d %*d %c %*d %s\n", nameW, r.Name, startW, r.Start, sizeW, r.Size, r.Strand, srcW, r.SrcSize, r.Text)
+		if r.Quality != "" {
+			fmt.Fprintf(w.w, "q %-*s %s\n", pad, r.Name, r.Quality)
+		}
+	}
+	if b.Probs != "" {
+		fmt.Fprintf(w.w, "p %-*s %s\n", pad, "", b.Probs)
+	}
+	_, err := w.w.WriteString("\n")
+	return err
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *MAFWriter) Flush() error { return w.w.Flush() }
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
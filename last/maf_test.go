@@ -0,0 +1,113 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+// readMAF returns the blocks in the MAF file at path.
+func readMAF(c *check.C, path string) []Block {
+	f, err := os.Open(path)
+	c.Assert(err, check.Equals, nil)
+	defer f.Close()
+	r := NewMAFReader(f)
+	var blocks []Block
+	for {
+		b, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, check.Equals, nil)
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+func (s *P) TestMAFReader(c *check.C) {
+	f, err := os.Open(filepath.Join("testdata", "align.maf"))
+	c.Assert(err, check.Equals, nil)
+	defer f.Close()
+	r := NewMAFReader(f)
+
+	b, err := r.Read()
+	c.Assert(err, check.Equals, nil)
+	c.Check(r.Comments[0], check.Equals, " LAST version 1179")
+	c.Check(b.Score, check.Equals, 41.0)
+	c.Check(b.Attrs, check.DeepEquals, []Attr{{"EG2", "1.4e+04"}, {"E", "5.9e-05"}})
+	e, err := b.FloatAttr("E")
+	c.Check(err, check.Equals, nil)
+	c.Check(e, check.Equals, 5.9e-05)
+	_, err = b.FloatAttr("X")
+	c.Check(err, check.ErrorMatches, "last: no X attribute")
+	c.Assert(b.Rows, check.HasLen, 2)
+	c.Check(b.Rows[0].Name, check.Equals, "chr1")
+	c.Check(b.Rows[0].Start, check.Equals, 1204)
+	c.Check(b.Rows[0].End(), check.Equals, 1250)
+	c.Check(b.Rows[0].Strand, check.Equals, byte('+'))
+	c.Check(b.Rows[0].SrcSize, check.Equals, 2000)
+	c.Check(b.Rows[0].Quality, check.Equals, "")
+	c.Check(b.Rows[1].Strand, check.Equals, byte('-'))
+	c.Check(b.Rows[1].Quality, check.Equals, "IIIII5555555555IIIIIIIIIIIIIIIIIIIIIIIIIII--III")
+	c.Check(b.Probs, check.Equals, "~~~~~~~~~~~~~~~~~~~~%%%%%%%%%%~~~~~~~~~~~~~~~~~")
+
+	b, err = r.Read()
+	c.Assert(err, check.Equals, nil)
+	c.Check(b.Score, check.Equals, 27.0)
+	c.Check(b.Probs, check.Equals, "")
+	_, err = r.Read()
+	c.Check(err, check.Equals, io.EOF)
+	c.Check(r.Comments[len(r.Comments)-1], check.Equals, " Query sequences=2")
+
+	for _, t := range []struct {
+		in  string
+		err string
+	}{
+		{in: "s chr1 0 1 + 1 A\n", err: "last: maf line 1: s line outside block"},
+		{in: "a score=x\n", err: `last: maf line 1: invalid score "x"`},
+		{in: "a score=1\ns chr1 0 1 * 1 A\n", err: `last: maf line 2: invalid strand "\*"`},
+		{in: "a score=1\ns chr1 0 1 + 1 A\nq chr2 I\n", err: "last: maf line 3: q line for chr2 does not follow its s line"},
+		{in: "a score=1\ns chr1 0 1 + 1\n", err: "last: maf line 2: invalid s line"},
+	} {
+		_, err := NewMAFReader(strings.NewReader(t.in)).Read()
+		c.Check(err, check.ErrorMatches, t.err)
+	}
+}
+
+func (s *P) TestMAFRoundTrip(c *check.C) {
+	path := filepath.Join("testdata", "align.maf")
+	blocks := readMAF(c, path)
+
+	var buf bytes.Buffer
+	w := NewMAFWriter(&buf)
+	for _, b := range blocks {
+		c.Assert(w.Write(b), check.Equals, nil)
+	}
+	c.Assert(w.Flush(), check.Equals, nil)
+
+	want, err := ioutil.ReadFile(path)
+	c.Assert(err, check.Equals, nil)
+	var lines []string
+	for _, l := range strings.SplitAfter(string(want), "\n") {
+		if !strings.HasPrefix(l, "#") {
+			lines = append(lines, l)
+		}
+	}
+	c.Check(buf.String(), check.Equals, strings.Join(lines, ""))
+
+	got := NewMAFReader(&buf)
+	for _, b := range blocks {
+		r, err := got.Read()
+		c.Assert(err, check.Equals, nil)
+		c.Check(r, check.DeepEquals, b)
+	}
+}
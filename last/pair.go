@@ -0,0 +1,184 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/biogo/external"
+)
+
+// PairProbs is a builder for last-pair-probs, which was named last-pair in older
+// versions of LAST. The alignments are written to standard output.
+type PairProbs struct {
+	// Usage: last-pair-probs [options] alignments1 alignments2
+	// Read alignments of paired DNA reads to a genome, and: (1) estimate the
+	// distribution of distances between paired reads, (2) estimate the probability
+	// that each alignment represents the genomic source of the read.
+	//
+	// Options (default settings):
+	//  -r: specifies that the fragments are from RNA
+	//  -e: just estimate the fragment length distribution
+	//  -f: mean fragment length in bp
+	//  -s: standard deviation of fragment length in bp
+	//  -d: prior probability of disjoint mapping (0.02 if -r, else 0.01)
+	//  -m: don't write alignments with mismap probability > PROB (0.01)
+	//  -c: specifies that chromosome CHROM is circular (chrM)
+	//
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}last-pair-probs{{end}}" doc:"last-pair-probs"`
+
+	// Options:
+	RNA          bool     `flag:"-r" doc:"fragments are from RNA"`
+	EstimateDist bool     `flag:"-e" doc:"just estimate the fragment length distribution"`
+	FragLen      float64  `opt:"-f" doc:"mean fragment length"`
+	SDev         float64  `opt:"-s" doc:"standard deviation of fragment length"`
+	Disjoint     float64  `opt:"-d" doc:"prior probability of disjoint mapping"`
+	MaxMismap    float64  `opt:"-m" doc:"maximum mismap probability"`
+	Circular     []string `opt:"-c,repeat" doc:"circular chromosomes"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
+
+	// Files:
+	InFiles []string `pos:"" path:"in" doc:"<in1.maf> <in2.maf>"`
+}
+
+func (p PairProbs) BuildCommand() (*exec.Cmd, error) {
+	if len(p.InFiles) == 0 {
+		return nil, ErrMissingRequired
+	}
+	if len(p.InFiles) > 2 {
+		return nil, fmt.Errorf("last: last-pair-probs takes at most 2 alignment files, have %d", len(p.InFiles))
+	}
+	cl, err := external.Build(p)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
+}
+
+// Mapping is an alignment of one mate of a read pair written by last-pair-probs.
+type Mapping struct {
+	// Alignment is the alignment of the read
+	// to the reference.
+	Alignment Block
+
+	// Mate is 1 or 2 for reads named with a
+	// /1 or /2 suffix, and 0 otherwise.
+	Mate int
+
+	// Mismap is the probability that the
+	// alignment does not represent the
+	// genomic source of the read.
+	Mismap float64
+}
+
+// AlignPairs aligns the paired reads in the FASTQ files fastq1 and fastq2 to the
+// database described by db, and returns the alignments written by last-pair-probs.
+//
+// The database named by db.OutFile must already exist; it is not built or updated,
+// and DB.Update may be used for that before calling AlignPairs. The lastal command for
+// each mate is configured by align, with its DB, InFiles and OutFile fields replaced,
+// and its InFormat set to Fastq unless it holds another fastq format. The
+// last-pair-probs command is configured by pair, with its InFiles field replaced. Each
+// command is run by r. Intermediate files are written to a temporary directory that is
+// removed before AlignPairs returns.
+func AlignPairs(ctx context.Context, r external.Runner, db DB, align Align, pair PairProbs, fastq1, fastq2 string) ([]Mapping, error) {
+	if db.OutFile == "" || fastq1 == "" || fastq2 == "" {
+		return nil, ErrMissingRequired
+	}
+	switch {
+	case align.Tabular:
+		return nil, fmt.Errorf("last: paired alignment requires MAF output")
+	case align.Format != "" && align.Format != MAF && align.Format != MAFPlus:
+		return nil, fmt.Errorf("last: paired alignment requires MAF output, have %s", align.Format)
+	}
+	switch align.InFormat {
+	case FastqSanger, FastqSolexa, FastqIllumina:
+	default:
+		align.InFormat = Fastq
+	}
+
+	tmp, err := ioutil.TempDir("", "last-pair-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	align.DB = db.OutFile
+	pair.InFiles = nil
+	for i, fq := range []string{fastq1, fastq2} {
+		align.InFiles = []string{fq}
+		align.OutFile = filepath.Join(tmp, fmt.Sprintf("mate%d.maf", i+1))
+		err = run(ctx, r, align, nil)
+		if err != nil {
+			return nil, err
+		}
+		pair.InFiles = append(pair.InFiles, align.OutFile)
+	}
+
+	out, err := os.Create(filepath.Join(tmp, "pairs.maf"))
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+	err = run(ctx, r, pair, out)
+	if err != nil {
+		return nil, err
+	}
+	_, err = out.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	return readMappings(out)
+}
+
+// run builds the command for cb and runs it with r, writing its standard output
+// to stdout.
+func run(ctx context.Context, r external.Runner, cb external.CommandBuilder, stdout io.Writer) error {
+	cmd, err := cb.BuildCommand()
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = stdout
+	return r.For(cb).Run(ctx, cmd)
+}
+
+// readMappings reads the MAF alignments written by last-pair-probs from r.
+func readMappings(r io.Reader) ([]Mapping, error) {
+	mr := NewMAFReader(r)
+	var m []Mapping
+	for {
+		b, err := mr.Read()
+		if err == io.EOF {
+			return m, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(b.Rows) != 2 {
+			return nil, fmt.Errorf("last: paired alignment has %d rows", len(b.Rows))
+		}
+		mismap, err := b.FloatAttr("mismap")
+		if err != nil {
+			return nil, err
+		}
+		var mate int
+		switch name := b.Rows[1].Name; {
+		case strings.HasSuffix(name, "/1"):
+			mate = 1
+		case strings.HasSuffix(name, "/2"):
+			mate = 2
+		}
+		m = append(m, Mapping{Alignment: b, Mate: mate, Mismap: mismap})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *P) TestPairProbsArgs(c *check.C) {
+	args, err := external.Build(PairProbs{
+		FragLen:  300,
+		SDev:     30,
+		Circular: []string{"chrM", "chrC"},
+		InFiles:  []string{"a.maf", "b.maf"},
+	})
+	c.Check(err, check.Equals, nil)
+	c.Check(args, check.DeepEquals, []string{
+		"last-pair-probs", "-f", "300", "-s", "30", "-c", "chrM", "-c", "chrC", "a.maf", "b.maf",
+	})
+
+	_, err = PairProbs{}.BuildCommand()
+	c.Check(err, check.Equals, ErrMissingRequired)
+	_, err = PairProbs{InFiles: []string{"a", "b", "c"}}.BuildCommand()
+	c.Check(err, check.ErrorMatches, "last: last-pair-probs takes at most 2 alignment files, have 3")
+}
+
+func (s *P) TestAlignPairs(c *check.C) {
+	// The fake lastal writes one alignment of the first
+	// read of its query file and records its -Q value.
+	defer fakeTool(c, "lastal", `
+while [ $# -gt 0 ]; do
+	case $1 in
+	-o) out=$2; shift 2;;
+	-Q) q=$2; shift 2;;
+	-*) shift 2;;
+	*) break;;
+	esac
+done
+name=$(head -n 1 "$2" | cut -c 2-)
+printf 'a score=30 Q=%s\ns chr1 10 4 + 100 ACGT\ns %s 0 4 + 4 ACGT\n\n' "$q" "$name" > "$out"
+`)()
+	// The fake last-pair-probs adds a mismap attribute
+	// to the alignments in its input files.
+	defer fakeTool(c, "last-pair-probs", `
+while [ $# -gt 0 ]; do
+	case $1 in
+	-*) shift 2;;
+	*) break;;
+	esac
+done
+sed 's/^a \(.*\)$/a \1 mismap=1e-05/' "$@"
+`)()
+
+	dir := c.MkDir()
+	fa := filepath.Join(dir, "ref.fa")
+	c.Assert(ioutil.WriteFile(fa, []byte(">chr1\nACGT\n"), 0644), check.Equals, nil)
+	var fqs []string
+	for _, name := range []string{"r/1", "r/2"} {
+		fq := filepath.Join(dir, "r"+name[2:]+".fq")
+		c.Assert(ioutil.WriteFile(fq, []byte("@"+name+"\nACGT\n+\nIIII\n"), 0644), check.Equals, nil)
+		fqs = append(fqs, fq)
+	}
+	db := DB{OutFile: filepath.Join(dir, "ref"), InFiles: []string{fa}}
+
+	m, err := AlignPairs(context.Background(), external.Runner{}, db, Align{}, PairProbs{FragLen: 300}, fqs[0], fqs[1])
+	c.Assert(err, check.Equals, nil)
+	c.Assert(m, check.HasLen, 2)
+	for i, mp := range m {
+		c.Check(mp.Mate, check.Equals, i+1)
+		c.Check(mp.Mismap, check.Equals, 1e-05)
+		c.Check(mp.Alignment.Rows[0].Name, check.Equals, "chr1")
+		q, _ := mp.Alignment.Attr("Q")
+		c.Check(q, check.Equals, "1")
+	}
+
+	// The database is used as is.
+	_, err = os.Stat(db.OutFile + ManifestSuffix)
+	c.Check(os.IsNotExist(err), check.Equals, true)
+
+	_, err = AlignPairs(context.Background(), external.Runner{}, db, Align{Format: Tab}, PairProbs{}, fqs[0], fqs[1])
+	c.Check(err, check.ErrorMatches, "last: paired alignment requires MAF output, have TAB")
+}
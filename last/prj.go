@@ -0,0 +1,189 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	dnaAlphabet     = "ACGT"
+	proteinAlphabet = "ACDEFGHIKLMNPQRSTVWY"
+)
+
+// DBInfo is the description of a lastdb database held in its .prj file.
+type DBInfo struct {
+	// Name is the name of the database, the
+	// OutFile of the DB that built it, or the
+	// name of a volume.
+	Name string
+
+	// Version is the version of lastdb that
+	// built the database, or zero if unknown.
+	Version int
+
+	// Alphabet is the alphabet of the database,
+	// for example "ACGT" for DNA.
+	Alphabet string
+
+	// Sequences and Letters are the numbers of
+	// sequences and letters in the database.
+	Sequences int
+	Letters   int
+
+	// MaskLowercase indicates that lowercase
+	// letters are soft-masked, given by the
+	// Softmask option of DB.
+	MaskLowercase bool
+
+	// Seed holds the spaced or subset seed
+	// pattern lines of the database, or is
+	// empty.
+	Seed []string
+
+	// Volumes holds the descriptions of the
+	// volumes of a database built with a
+	// VolumeSize, in order, or is empty.
+	Volumes []*DBInfo
+
+	// Attrs holds every key=value pair of the
+	// .prj file in order.
+	Attrs []Attr
+}
+
+// Protein returns whether the database was built from protein sequences.
+func (i *DBInfo) Protein() bool { return i.Alphabet == proteinAlphabet }
+
+// DNA returns whether the database was built from DNA sequences.
+func (i *DBInfo) DNA() bool { return i.Alphabet == dnaAlphabet }
+
+// ReadDBInfo returns the description of the lastdb database name read from name.prj.
+// If the database has volumes, the description of each volume is read from its own
+// .prj file, name0.prj, name1.prj and so on.
+func ReadDBInfo(name string) (*DBInfo, error) {
+	info, err := readPrjFile(name)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := attr(info.Attrs, "volumes")
+	if !ok {
+		return info, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("last: %s.prj: invalid volumes %q", name, v)
+	}
+	for i := 0; i < n; i++ {
+		vol, err := readPrjFile(name + strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		info.Volumes = append(info.Volumes, vol)
+	}
+	return info, nil
+}
+
+func readPrjFile(name string) (*DBInfo, error) {
+	f, err := os.Open(name + ".prj")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := readPrj(f, name+".prj")
+	if err != nil {
+		return nil, err
+	}
+	info.Name = name
+	return info, nil
+}
+
+// ReadPrj reads the description of a lastdb database or volume from the contents of a
+// .prj file. Volumes are not read and the Name field of the returned DBInfo is empty.
+func ReadPrj(r io.Reader) (*DBInfo, error) { return readPrj(r, "prj") }
+
+// readPrj reads a .prj file from r, reporting errors against the file name.
+func readPrj(r io.Reader, name string) (*DBInfo, error) {
+	var (
+		info DBInfo
+		line int
+		key  string
+	)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line++
+		l := strings.TrimSpace(sc.Text())
+		if l == "" {
+			continue
+		}
+		i := strings.Index(l, "=")
+		if i < 0 {
+			// Seed patterns may continue
+			// over following lines.
+			if key == "subsetseed" {
+				info.Seed = append(info.Seed, l)
+				continue
+			}
+			return nil, fmt.Errorf("last: %s line %d: invalid line %q", name, line, l)
+		}
+		var val string
+		key, val = l[:i], l[i+1:]
+		info.Attrs = append(info.Attrs, Attr{Key: key, Value: val})
+
+		var (
+			dst *int
+			err error
+		)
+		switch key {
+		case "version":
+			dst = &info.Version
+		case "alphabet":
+			info.Alphabet = val
+		case "numofsequences":
+			dst = &info.Sequences
+		case "numofletters":
+			dst = &info.Letters
+		case "masklowercase":
+			info.MaskLowercase = val == "1"
+		case "subsetseed":
+			if val != "" {
+				info.Seed = append(info.Seed, val)
+			}
+		}
+		if dst != nil {
+			*dst, err = strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("last: %s line %d: invalid %s %q", name, line, key, val)
+			}
+		}
+	}
+	err := sc.Err()
+	if err != nil {
+		return nil, err
+	}
+	if info.Alphabet == "" {
+		return nil, fmt.Errorf("last: %s: no alphabet", name)
+	}
+	return &info, nil
+}
+
+// CheckDB returns an error if the options of a are not consistent with the database
+// described by info. Translated alignment, given by FrameShiftCost or GenCodeFile,
+// requires a protein database, and the quality-aware fastq and prb input formats
+// otherwise require a DNA database.
+func (a Align) CheckDB(info *DBInfo) error {
+	translated := a.FrameShiftCost != 0 || a.GenCodeFile != ""
+	switch {
+	case translated && !info.Protein():
+		return fmt.Errorf("last: translated alignment requires a protein database: %s has alphabet %s", info.Name, info.Alphabet)
+	case !translated && info.Protein() && FastqSanger <= a.InFormat && a.InFormat <= PRB:
+		return fmt.Errorf("last: %v input requires a DNA database: %s is a protein database", a.InFormat, info.Name)
+	}
+	return nil
+}
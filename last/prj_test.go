@@ -0,0 +1,116 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *P) TestReadDBInfo(c *check.C) {
+	name := filepath.Join("testdata", "dna")
+	info, err := ReadDBInfo(name)
+	c.Assert(err, check.Equals, nil)
+	c.Check(info.Name, check.Equals, name)
+	c.Check(info.Version, check.Equals, 1179)
+	c.Check(info.DNA(), check.Equals, true)
+	c.Check(info.Protein(), check.Equals, false)
+	c.Check(info.Sequences, check.Equals, 2)
+	c.Check(info.Letters, check.Equals, 2400)
+	c.Check(info.MaskLowercase, check.Equals, true)
+	c.Check(info.Seed, check.HasLen, 0)
+	c.Assert(info.Volumes, check.HasLen, 2)
+	for i, letters := range []int{2000, 400} {
+		vol := info.Volumes[i]
+		c.Check(vol.Name, check.Equals, name+string(rune('0'+i)))
+		c.Check(vol.Sequences, check.Equals, 1)
+		c.Check(vol.Letters, check.Equals, letters)
+		c.Check(vol.Seed, check.DeepEquals, []string{
+			"A C G T", "AG CT", "A C G T", "ACGT", "ACGT", "A C G T",
+			"A C G T", "ACGT", "ACGT", "A C G T", "ACGT", "A C G T",
+		})
+	}
+
+	info, err = ReadDBInfo(filepath.Join("testdata", "protein"))
+	c.Assert(err, check.Equals, nil)
+	c.Check(info.Protein(), check.Equals, true)
+	c.Check(info.MaskLowercase, check.Equals, false)
+	c.Check(info.Volumes, check.HasLen, 0)
+	v, ok := attr(info.Attrs, "integersize")
+	c.Check(ok, check.Equals, true)
+	c.Check(v, check.Equals, "32")
+
+	info, err = ReadPrj(strings.NewReader("alphabet=ACGT\nsubsetseed=AG CT\nA C G T\n\nsubsetseed=\nACGT\n"))
+	c.Assert(err, check.Equals, nil)
+	c.Check(info.Seed, check.DeepEquals, []string{"AG CT", "A C G T", "ACGT"})
+
+	for _, t := range []struct {
+		in  string
+		err string
+	}{
+		{"numofletters=10\n", "last: prj: no alphabet"},
+		{"alphabet=ACGT\nnumofletters=x\n", `last: prj line 2: invalid numofletters "x"`},
+		{"alphabet=ACGT\nAG CT\n", `last: prj line 2: invalid line "AG CT"`},
+	} {
+		_, err = ReadPrj(strings.NewReader(t.in))
+		c.Check(err, check.ErrorMatches, t.err)
+	}
+}
+
+func (s *P) TestCheckDB(c *check.C) {
+	dna, err := ReadDBInfo(filepath.Join("testdata", "dna"))
+	c.Assert(err, check.Equals, nil)
+	protein, err := ReadDBInfo(filepath.Join("testdata", "protein"))
+	c.Assert(err, check.Equals, nil)
+
+	for _, t := range []struct {
+		a    Align
+		info *DBInfo
+		err  string
+	}{
+		{a: Align{InFormat: Fastq}, info: dna},
+		{a: Align{}, info: protein},
+		{a: Align{FrameShiftCost: 15, InFormat: Fastq}, info: protein},
+		{a: Align{FrameShiftCost: 15}, info: dna, err: "last: translated alignment requires a protein database: testdata/dna has alphabet ACGT"},
+		{a: Align{InFormat: PRB}, info: protein, err: "last: PRB input requires a DNA database: testdata/protein is a protein database"},
+	} {
+		err := t.a.CheckDB(t.info)
+		if t.err == "" {
+			c.Check(err, check.Equals, nil)
+		} else {
+			c.Check(err, check.ErrorMatches, t.err)
+		}
+	}
+
+	a := Align{DBCheck: true, GenCodeFile: "gc", DB: filepath.Join("testdata", "dna"), InFiles: []string{"in.fa"}}
+	_, err = a.BuildCommand()
+	c.Check(err, check.ErrorMatches, "last: translated alignment requires a protein database: .*")
+}
+
+func (s *S) TestReadDBInfoLastdb(c *check.C) {
+	dir := c.MkDir()
+	fa := filepath.Join(dir, "ref.fa")
+	err := ioutil.WriteFile(fa, []byte(">chr1\n"+strings.Repeat("GATTACACGT", 20)+"\n>chr2\n"+strings.Repeat("ACGTTGCA", 10)+"\n"), 0644)
+	c.Assert(err, check.Equals, nil)
+	name := filepath.Join(dir, "ref")
+	cmd, err := DB{OutFile: name, InFiles: []string{fa}}.BuildCommand()
+	c.Assert(err, check.Equals, nil)
+	out, err := cmd.CombinedOutput()
+	c.Assert(err, check.Equals, nil, check.Commentf("%s", out))
+
+	info, err := ReadDBInfo(name)
+	c.Assert(err, check.Equals, nil)
+	c.Check(info.DNA(), check.Equals, true)
+	c.Check(info.Sequences, check.Equals, 2)
+	c.Check(info.Letters, check.Equals, 280)
+	c.Check(info.Version > 0, check.Equals, true)
+	c.Check(info.Seed, check.Not(check.HasLen), 0)
+	for _, l := range info.Seed {
+		c.Check(strings.Trim(l, info.Alphabet+" "), check.Equals, "", check.Commentf("seed line %q", l))
+	}
+}
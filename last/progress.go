@@ -0,0 +1,42 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"strings"
+
+	"github.com/biogo/external"
+)
+
+// progress is a ProgressParser for the verbose messages written by the last tools.
+// Messages are of the form "lastal: message..." and give the stage of the run, but
+// not the fraction of the stage completed.
+type progress struct {
+	prefix string
+}
+
+func (p progress) ParseProgress(line string) (external.Progress, bool) {
+	if !strings.HasPrefix(line, p.prefix) {
+		return external.Progress{}, false
+	}
+	stage := strings.TrimSpace(line[len(p.prefix):])
+	frac := -1.0
+	if strings.HasPrefix(stage, "done") {
+		frac = 1
+	}
+	stage = strings.TrimRight(stage, ".!")
+	if stage == "" {
+		return external.Progress{}, false
+	}
+	return external.Progress{Stage: stage, Fraction: frac, Line: line}, true
+}
+
+// ProgressParser returns a new parser for the messages written by lastdb when
+// db.Verbose is set.
+func (db DB) ProgressParser() external.ProgressParser { return progress{prefix: "lastdb:"} }
+
+// ProgressParser returns a new parser for the messages written by lastal when
+// a.Verbose is set.
+func (a Align) ProgressParser() external.ProgressParser { return progress{prefix: "lastal:"} }
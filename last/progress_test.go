@@ -0,0 +1,40 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+// P is the suite of tests that do not require the last suite.
+type P struct{}
+
+var _ = check.Suite(&P{})
+
+func (s *P) TestProgress(c *check.C) {
+	for _, t := range []struct {
+		cb   external.Progresser
+		line string
+		ok   bool
+		want external.Progress
+	}{
+		{cb: DB{}, line: "lastdb: reading in.fa", ok: true, want: external.Progress{Stage: "reading in.fa", Fraction: -1}},
+		{cb: DB{}, line: "lastdb: sorting...", ok: true, want: external.Progress{Stage: "sorting", Fraction: -1}},
+		{cb: DB{}, line: "lastdb: done!", ok: true, want: external.Progress{Stage: "done", Fraction: 1}},
+		{cb: DB{}, line: "lastal: reading the database"},
+		{cb: Align{}, line: "lastal: initializing...", ok: true, want: external.Progress{Stage: "initializing", Fraction: -1}},
+		{cb: Align{}, line: "lastal: query batch done!", ok: true, want: external.Progress{Stage: "query batch done", Fraction: -1}},
+		{cb: Align{}, line: "warning: something"},
+	} {
+		got, ok := t.cb.ProgressParser().ParseProgress(t.line)
+		c.Check(ok, check.Equals, t.ok)
+		if ok {
+			t.want.Line = t.line
+		}
+		c.Check(got, check.DeepEquals, t.want)
+	}
+}
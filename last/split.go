@@ -0,0 +1,82 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/biogo/external"
+)
+
+// Split is a builder for last-split. With no InFiles, last-split reads MAF from its
+// standard input, so a Split may follow an Align that writes to standard output in an
+// external.Pipeline.
+type Split struct {
+	// Usage: last-split [options] LAST-alignments.maf
+	// Read alignments of query sequences to a genome, and estimate the genomic source
+	// of each part of each query.
+	//
+	// Options (default settings):
+	//  -f: output format: MAF, MAF+ (MAF+)
+	//  -r: reverse the roles of the two sequences in each alignment
+	//  -m: maximum mismap probability (1)
+	//  -s: minimum alignment score (1.0 * the MAF's threshold)
+	//  -n: write the original, not split, alignments
+	//  -v: be verbose
+	//
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}last-split{{end}}" doc:"last-split"`
+
+	// Options:
+	Format    Format  `opt:"-f" doc:"output format"`
+	Reverse   bool    `flag:"-r" doc:"reverse the roles of the two sequences"`
+	MaxMismap float64 `opt:"-m" doc:"maximum mismap probability"`
+	MinScore  int     `opt:"-s" doc:"minimum alignment score"`
+	NoSplit   bool    `flag:"-n" doc:"write the original alignments"`
+	Verbose   bool    `flag:"-v" doc:"be verbose"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
+
+	// Files:
+	InFiles []string `pos:"" path:"in" doc:"<in.maf>..."`
+}
+
+func (s Split) BuildCommand() (*exec.Cmd, error) {
+	switch s.Format {
+	case "", MAF, MAFPlus:
+	default:
+		return nil, fmt.Errorf("last: invalid last-split output format %q", string(s.Format))
+	}
+	cl, err := external.Build(s)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
+}
+
+// PostMask is a builder for last-postmask. With no InFiles, last-postmask reads MAF
+// from its standard input.
+type PostMask struct {
+	// Usage: last-postmask [options] LAST-alignments.maf
+	// Remove alignments that have a score below the threshold after discarding the
+	// contribution of lowercase letters.
+	//
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}last-postmask{{end}}" doc:"last-postmask"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
+
+	// Files:
+	InFiles []string `pos:"" path:"in" doc:"<in.maf>..."`
+}
+
+func (p PostMask) BuildCommand() (*exec.Cmd, error) {
+	cl, err := external.Build(p)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
+}
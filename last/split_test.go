@@ -0,0 +1,35 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *P) TestSplitArgs(c *check.C) {
+	for _, t := range []struct {
+		cb   external.CommandBuilder
+		args []string
+	}{
+		{Split{}, []string{"last-split"}},
+		{
+			Split{Format: MAF, Reverse: true, MaxMismap: 1e-05, MinScore: 150, NoSplit: true, InFiles: []string{"a.maf", "-b.maf"}},
+			[]string{"last-split", "-f", "MAF", "-r", "-m", "1e-05", "-s", "150", "-n", "a.maf", "./-b.maf"},
+		},
+		{PostMask{}, []string{"last-postmask"}},
+		{PostMask{InFiles: []string{"a.maf"}}, []string{"last-postmask", "a.maf"}},
+	} {
+		args, err := external.Build(t.cb)
+		c.Check(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, t.args)
+	}
+
+	_, err := Split{Format: Tab}.BuildCommand()
+	c.Check(err, check.ErrorMatches, `last: invalid last-split output format "TAB"`)
+	_, err = external.Build(Split{Extra: []string{"-m0.01"}})
+	c.Check(err, check.ErrorMatches, `external: extra argument "-m0.01" clashes with field MaxMismap`)
+}
@@ -0,0 +1,320 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TabSeq is the description of one of the sequences of a tabular alignment record.
+type TabSeq struct {
+	// Name is the name of the sequence.
+	Name string
+
+	// Start is the zero-based start of the aligned
+	// region on the given strand and Size is the
+	// number of letters in the aligned region.
+	Start int
+	Size  int
+
+	// Strand is '+' or '-'.
+	Strand byte
+
+	// SrcSize is the length of the sequence.
+	SrcSize int
+}
+
+// Segment is a gapless aligned segment of a tabular alignment record. Starts are
+// zero-based and on the strand of the aligned sequence. Size is the number of aligned
+// letters; in translated alignments it counts amino acids, and the segment covers
+// three times as many nucleotides of the translated sequence.
+type Segment struct {
+	Start1 int
+	Start2 int
+	Size   int
+}
+
+// TabRecord is an alignment in LAST tabular format, written by lastal -f 0.
+type TabRecord struct {
+	// Score is the alignment score.
+	Score float64
+
+	// Seq1 and Seq2 describe the aligned
+	// reference and query sequences.
+	Seq1 TabSeq
+	Seq2 TabSeq
+
+	// Segments holds the gapless aligned segments
+	// described by the blocks column.
+	Segments []Segment
+
+	// Attrs holds the key=value pairs that follow
+	// the blocks column, such as EG2 and E.
+	Attrs []Attr
+}
+
+// Attr returns the value of the attribute key and whether it is present.
+func (r TabRecord) Attr(key string) (string, bool) {
+	return attr(r.Attrs, key)
+}
+
+// lineReader reads the non-comment lines of a line-based format.
+type lineReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+// next returns the next non-empty line that is not a comment, split at tabs.
+func (r *lineReader) next() ([]string, error) {
+	for {
+		l, err := r.r.ReadString('\n')
+		if err != nil && (err != io.EOF || l == "") {
+			return nil, err
+		}
+		r.line++
+		l = strings.TrimRight(l, "\r\n")
+		if l == "" || l[0] == '#' {
+			continue
+		}
+		return strings.Split(l, "\t"), nil
+	}
+}
+
+func (r *lineReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("last: line %d: %s", r.line, fmt.Sprintf(format, args...))
+}
+
+// TabReader reads alignments in LAST tabular format. Comment lines are skipped.
+type TabReader struct {
+	lineReader
+}
+
+// NewTabReader returns a TabReader that reads from r.
+func NewTabReader(r io.Reader) *TabReader {
+	return &TabReader{lineReader{r: bufio.NewReader(r)}}
+}
+
+// Read returns the next record. At the end of the input, Read returns io.EOF.
+func (r *TabReader) Read() (TabRecord, error) {
+	f, err := r.next()
+	if err != nil {
+		return TabRecord{}, err
+	}
+	if len(f) < 12 {
+		return TabRecord{}, r.errorf("too few fields: %d", len(f))
+	}
+	var rec TabRecord
+	rec.Score, err = strconv.ParseFloat(f[0], 64)
+	if err != nil {
+		return TabRecord{}, r.errorf("invalid score %q", f[0])
+	}
+	rec.Seq1, err = r.parseSeq(f[1:6])
+	if err != nil {
+		return TabRecord{}, err
+	}
+	rec.Seq2, err = r.parseSeq(f[6:11])
+	if err != nil {
+		return TabRecord{}, err
+	}
+	rec.Segments, err = r.parseBlocks(f[11], rec.Seq1, rec.Seq2)
+	if err != nil {
+		return TabRecord{}, err
+	}
+	for _, kv := range f[12:] {
+		i := strings.Index(kv, "=")
+		if i < 0 {
+			return TabRecord{}, r.errorf("invalid attribute %q", kv)
+		}
+		rec.Attrs = append(rec.Attrs, Attr{Key: kv[:i], Value: kv[i+1:]})
+	}
+	return rec, nil
+}
+
+func (r *TabReader) parseSeq(f []string) (TabSeq, error) {
+	var (
+		s   TabSeq
+		err error
+	)
+	s.Name = f[0]
+	s.Start, err = strconv.Atoi(f[1])
+	if err != nil {
+		return TabSeq{}, r.errorf("invalid start %q", f[1])
+	}
+	s.Size, err = strconv.Atoi(f[2])
+	if err != nil {
+		return TabSeq{}, r.errorf("invalid size %q", f[2])
+	}
+	if f[3] != "+" && f[3] != "-" {
+		return TabSeq{}, r.errorf("invalid strand %q", f[3])
+	}
+	s.Strand = f[3][0]
+	s.SrcSize, err = strconv.Atoi(f[4])
+	if err != nil {
+		return TabSeq{}, r.errorf("invalid source size %q", f[4])
+	}
+	return s, nil
+}
+
+// parseBlocks returns the segments described by a blocks column. The column is a
+// comma separated list of the sizes of gapless blocks and the numbers of unaligned
+// letters of each sequence between blocks, given as x:y.
+//
+// In translated alignments, written by lastal -F, block sizes count amino acids
+// while the coordinates and gaps of the translated DNA sequence count nucleotides,
+// so each block covers three times its size in that sequence. Gaps in the DNA
+// sequence may be negative or not a multiple of three where there is a frameshift.
+// The translated sequence is found by checking which scaling of the block sizes
+// matches the aligned sizes of the two sequences.
+func (r *TabReader) parseBlocks(blocks string, s1, s2 TabSeq) ([]Segment, error) {
+	type block struct {
+		size, gap1, gap2 int
+		isGap            bool
+	}
+	var (
+		parsed          []block
+		sum, gap1, gap2 int
+		neg1, neg2      bool
+	)
+	for _, b := range strings.Split(blocks, ",") {
+		if i := strings.Index(b, ":"); i >= 0 {
+			g1, err1 := strconv.Atoi(b[:i])
+			g2, err2 := strconv.Atoi(b[i+1:])
+			if err1 != nil || err2 != nil {
+				return nil, r.errorf("invalid gap %q", b)
+			}
+			neg1 = neg1 || g1 < 0
+			neg2 = neg2 || g2 < 0
+			parsed = append(parsed, block{gap1: g1, gap2: g2, isGap: true})
+			gap1 += g1
+			gap2 += g2
+			continue
+		}
+		n, err := strconv.Atoi(b)
+		if err != nil || n < 0 {
+			return nil, r.errorf("invalid block %q", b)
+		}
+		parsed = append(parsed, block{size: n})
+		sum += n
+	}
+
+	// Find the scale of blocks in each sequence,
+	// where 3 marks a translated sequence.
+	var scale1, scale2 int
+	for _, f := range [][2]int{{1, 1}, {1, 3}, {3, 1}} {
+		if (neg1 && f[0] == 1) || (neg2 && f[1] == 1) {
+			continue
+		}
+		if f[0]*sum+gap1 == s1.Size && f[1]*sum+gap2 == s2.Size {
+			scale1, scale2 = f[0], f[1]
+			break
+		}
+	}
+	if scale1 == 0 {
+		return nil, r.errorf("blocks %q do not match aligned sizes", blocks)
+	}
+
+	var segs []Segment
+	pos1, pos2 := s1.Start, s2.Start
+	for _, b := range parsed {
+		if b.isGap {
+			pos1 += b.gap1
+			pos2 += b.gap2
+			continue
+		}
+		segs = append(segs, Segment{Start1: pos1, Start2: pos2, Size: b.size})
+		pos1 += scale1 * b.size
+		pos2 += scale2 * b.size
+	}
+	return segs, nil
+}
+
+// BlastTabRecord is an alignment in BLAST tabular format, written by lastal -f BlastTab
+// or -f BlastTab+. Positions are one-based and inclusive. The query is the sequence
+// read by lastal and the subject is the database sequence. For alignments to the
+// reverse strand, SubjectStart is greater than SubjectEnd.
+type BlastTabRecord struct {
+	Query           string
+	Subject         string
+	PercentIdentity float64
+	Length          int
+	Mismatches      int
+	GapOpens        int
+	QueryStart      int
+	QueryEnd        int
+	SubjectStart    int
+	SubjectEnd      int
+	EValue          float64
+	BitScore        float64
+
+	// QueryLen and SubjectLen are the lengths of
+	// the sequences given in BlastTab+ format, or
+	// zero.
+	QueryLen   int
+	SubjectLen int
+}
+
+// BlastTabReader reads alignments in BLAST tabular format. Comment lines are skipped.
+type BlastTabReader struct {
+	lineReader
+}
+
+// NewBlastTabReader returns a BlastTabReader that reads from r.
+func NewBlastTabReader(r io.Reader) *BlastTabReader {
+	return &BlastTabReader{lineReader{r: bufio.NewReader(r)}}
+}
+
+// Read returns the next record. At the end of the input, Read returns io.EOF.
+func (r *BlastTabReader) Read() (BlastTabRecord, error) {
+	f, err := r.next()
+	if err != nil {
+		return BlastTabRecord{}, err
+	}
+	if len(f) < 12 {
+		return BlastTabRecord{}, r.errorf("too few fields: %d", len(f))
+	}
+	rec := BlastTabRecord{Query: f[0], Subject: f[1]}
+	floats := []struct {
+		dst *float64
+		idx int
+	}{
+		{&rec.PercentIdentity, 2},
+		{&rec.EValue, 10},
+		{&rec.BitScore, 11},
+	}
+	for _, v := range floats {
+		*v.dst, err = strconv.ParseFloat(f[v.idx], 64)
+		if err != nil {
+			return BlastTabRecord{}, r.errorf("invalid field %d: %q", v.idx+1, f[v.idx])
+		}
+	}
+	ints := []struct {
+		dst *int
+		idx int
+	}{
+		{&rec.Length, 3},
+		{&rec.Mismatches, 4},
+		{&rec.GapOpens, 5},
+		{&rec.QueryStart, 6},
+		{&rec.QueryEnd, 7},
+		{&rec.SubjectStart, 8},
+		{&rec.SubjectEnd, 9},
+		{&rec.QueryLen, 12},
+		{&rec.SubjectLen, 13},
+	}
+	for _, v := range ints {
+		if v.idx >= len(f) {
+			break
+		}
+		*v.dst, err = strconv.Atoi(f[v.idx])
+		if err != nil {
+			return BlastTabRecord{}, r.errorf("invalid field %d: %q", v.idx+1, f[v.idx])
+		}
+	}
+	return rec, nil
+}
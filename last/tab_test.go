@@ -0,0 +1,106 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *P) TestTabReader(c *check.C) {
+	f, err := os.Open(filepath.Join("testdata", "align.tab"))
+	c.Assert(err, check.Equals, nil)
+	defer f.Close()
+	r := NewTabReader(f)
+
+	rec, err := r.Read()
+	c.Assert(err, check.Equals, nil)
+	c.Check(rec, check.DeepEquals, TabRecord{
+		Score: 41,
+		Seq1:  TabSeq{Name: "chr1", Start: 1204, Size: 46, Strand: '+', SrcSize: 2000},
+		Seq2:  TabSeq{Name: "read1", Start: 3, Size: 45, Strand: '-', SrcSize: 60},
+		Segments: []Segment{
+			{Start1: 1204, Start2: 3, Size: 28},
+			{Start1: 1232, Start2: 32, Size: 13},
+			{Start1: 1247, Start2: 45, Size: 3},
+		},
+		Attrs: []Attr{{"EG2", "1.4e+04"}, {"E", "5.9e-05"}},
+	})
+	e, ok := rec.Attr("E")
+	c.Check(ok, check.Equals, true)
+	c.Check(e, check.Equals, "5.9e-05")
+
+	rec, err = r.Read()
+	c.Assert(err, check.Equals, nil)
+	c.Check(rec.Segments, check.DeepEquals, []Segment{{Start1: 10, Start2: 0, Size: 20}})
+	_, err = r.Read()
+	c.Check(err, check.Equals, io.EOF)
+
+	// A translated alignment of a DNA query to a protein, in the form
+	// written by lastal -F, with a -1 frameshift in the query.
+	rec, err = NewTabReader(strings.NewReader("52\tprot1\t10\t20\t+\t300\tdna1\t100\t56\t+\t1000\t10,0:-1,5,1:0,4\n")).Read()
+	c.Assert(err, check.Equals, nil)
+	c.Check(rec.Segments, check.DeepEquals, []Segment{
+		{Start1: 10, Start2: 100, Size: 10},
+		{Start1: 20, Start2: 129, Size: 5},
+		{Start1: 26, Start2: 144, Size: 4},
+	})
+
+	for _, t := range []struct {
+		in  string
+		err string
+	}{
+		{in: "1\tchr1\t0\t1\t+\t1\n", err: "last: line 1: too few fields: 6"},
+		{in: "1\tchr1\t0\t2\t+\t9\tr\t0\t2\t+\t9\t1,x:1,1\n", err: `last: line 1: invalid gap "x:1"`},
+		{in: "1\tchr1\t0\t2\t+\t9\tr\t0\t2\t+\t9\t3\n", err: `last: line 1: blocks "3" do not match aligned sizes`},
+		{in: "1\tchr1\t0\t2\t+\t9\tr\t0\t2\t+\t9\t2,-1:0,1\n", err: `last: line 1: blocks "2,-1:0,1" do not match aligned sizes`},
+		{in: "1\tchr1\t0\t2\t?\t9\tr\t0\t2\t+\t9\t2\n", err: `last: line 1: invalid strand "\?"`},
+	} {
+		_, err := NewTabReader(strings.NewReader(t.in)).Read()
+		c.Check(err, check.ErrorMatches, t.err)
+	}
+}
+
+func (s *P) TestBlastTabReader(c *check.C) {
+	f, err := os.Open(filepath.Join("testdata", "align.blasttab"))
+	c.Assert(err, check.Equals, nil)
+	defer f.Close()
+	r := NewBlastTabReader(f)
+
+	rec, err := r.Read()
+	c.Assert(err, check.Equals, nil)
+	c.Check(rec, check.DeepEquals, BlastTabRecord{
+		Query:           "read1",
+		Subject:         "chr1",
+		PercentIdentity: 91.49,
+		Length:          47,
+		Mismatches:      1,
+		GapOpens:        2,
+		QueryStart:      13,
+		QueryEnd:        57,
+		SubjectStart:    1250,
+		SubjectEnd:      1205,
+		EValue:          5.9e-05,
+		BitScore:        22.9,
+		QueryLen:        60,
+		SubjectLen:      2000,
+	})
+	_, err = r.Read()
+	c.Assert(err, check.Equals, nil)
+	_, err = r.Read()
+	c.Check(err, check.Equals, io.EOF)
+
+	// Plain BlastTab has no sequence lengths.
+	rec, err = NewBlastTabReader(strings.NewReader("q\ts\t100\t5\t0\t0\t1\t5\t1\t5\t1e-3\t10.5\n")).Read()
+	c.Assert(err, check.Equals, nil)
+	c.Check(rec.QueryLen, check.Equals, 0)
+	c.Check(rec.BitScore, check.Equals, 10.5)
+	_, err = NewBlastTabReader(strings.NewReader("q\ts\t100\t5\t0\t0\t1\tx\t1\t5\t1e-3\t10.5\n")).Read()
+	c.Check(err, check.ErrorMatches, `last: line 1: invalid field 8: "x"`)
+}
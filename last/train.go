@@ -0,0 +1,331 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/biogo/external"
+)
+
+// Train is a builder for last-train. The trained parameters are written to the standard
+// output of the command and may be read with ReadParams. A file holding them may be
+// given to lastal with the ScoreFile field of Align or with Params.Apply.
+type Train struct {
+	// Usage: last-train [options] lastdb-name sequence-file(s)
+	// Try to find suitable score parameters for aligning the given sequences.
+	//
+	// Options (default settings):
+	//  -v: be verbose
+	//  --revsym: force reverse-complement symmetry
+	//  --matsym: force symmetric substitution matrix
+	//  --gapsym: force insertion/deletion symmetry
+	//  --pid: skip alignments with > PID% identity (100)
+	//  --postmask: skip mostly-lowercase alignments (1)
+	//  --sample-number: number of random sequence samples (20000)
+	//  --sample-length: length of each sample (2000)
+	//  --scale: output scores in units of 1/S bits
+	//  --codon: DNA queries & protein reference
+	//
+	// Initial parameter options:
+	//  -r: match score
+	//  -q: mismatch cost
+	//  -p: match/mismatch score matrix
+	//  -a: gap existence cost
+	//  -b: gap extension cost
+	//  -A: insertion existence cost
+	//  -B: insertion extension cost
+	//
+	// Alignment options:
+	//  -D: query letters per random alignment (1e6)
+	//  -E: maximum expected alignments per square giga
+	//  -s: strand: 0=reverse, 1=forward, 2=both (2)
+	//  -m: maximum initial matches per query position (10)
+	//  -k: use initial matches starting at every k-th position in each query (1)
+	//  -P: number of parallel threads
+	//  -Q: input format
+	//
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}last-train{{end}}" doc:"last-train"`
+
+	// Options:
+	Verbose      bool    `flag:"-v" doc:"be verbose"`
+	RevSym       bool    `flag:"--revsym" doc:"force reverse-complement symmetry"`
+	MatSym       bool    `flag:"--matsym" doc:"force symmetric substitution matrix"`
+	GapSym       bool    `flag:"--gapsym" doc:"force insertion/deletion symmetry"`
+	MaxPID       float64 `opt:"--pid,eq" doc:"skip alignments with > PID% identity"`
+	NoPostMask   bool    `flag:"--postmask=0" doc:"do not skip mostly-lowercase alignments"`
+	SampleNumber int     `opt:"--sample-number,eq" doc:"number of random sequence samples"`
+	SampleLength int     `opt:"--sample-length,eq" doc:"length of each sample"`
+	Scale        float64 `opt:"--scale,eq" doc:"output scores in units of 1/S bits"`
+	Codon        bool    `flag:"--codon" doc:"DNA queries & protein reference"`
+
+	// Initial parameter options:
+	MatchScore   int    `opt:"-r" doc:"match score"`
+	MismatchCost int    `opt:"-q" doc:"mismatch cost"`
+	ScoreFile    string `opt:"-p" path:"in" doc:"match/mismatch score matrix"`
+	GapCost      int    `opt:"-a" doc:"gap existence cost"`
+	ExtendCost   int    `opt:"-b" doc:"gap extension cost"`
+	InsertCost   int    `opt:"-A" doc:"insertion existence cost"`
+	InsertExtend int    `opt:"-B" doc:"insertion extension cost"`
+
+	// Alignment options:
+	QueryLetters float64  `opt:"-D" doc:"query letters per random alignment"`
+	MaxExpected  float64  `opt:"-E" doc:"maximum expected alignments per square giga"`
+	Strand       int      `opt:"-s" doc:"strand"`
+	MaxMultiple  int      `opt:"-m" doc:"maximum initial matches per query position"`
+	StepSize     int      `opt:"-k" doc:"step-size along the query sequence"`
+	Threads      int      `opt:"-P" doc:"number of parallel threads"`
+	InFormat     InFormat `opt:"-Q" doc:"input format"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
+
+	// Files:
+	DB      string   `pos:"" path:"in" doc:"<lastdb>"`
+	InFiles []string `pos:"" path:"in" doc:"<in.fa>..."`
+}
+
+// Resources returns the resources required by the command built by t. The number
+// of CPUs is given by t.Threads if it is positive.
+func (t Train) Resources() external.Resources {
+	var r external.Resources
+	if t.Threads > 0 {
+		r.CPUs = t.Threads
+	}
+	return r
+}
+
+func (t Train) BuildCommand() (*exec.Cmd, error) {
+	if t.DB == "" || len(t.InFiles) == 0 {
+		return nil, ErrMissingRequired
+	}
+	cl, err := external.Build(t)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
+}
+
+// Params holds the score parameters written by last-train. Options are read from the
+// "#last" lines of the parameter file and the substitution matrix from its uncommented
+// lines.
+type Params struct {
+	// File is the path of the parameter
+	// file, or empty if the parameters were
+	// not read from a named file.
+	File string
+
+	// Trained option values. Values not
+	// given in the file are zero.
+	MatchScore   int     // -r: match score
+	MismatchCost int     // -q: mismatch cost
+	GapCost      int     // -a: gap existence cost
+	ExtendCost   int     // -b: gap extension cost
+	InsertCost   int     // -A: insertion existence cost
+	InsertExtend int     // -B: insertion extension cost
+	Temperature  float64 // -t: 'temperature' for calculating probabilities
+
+	// Options holds every option given by the
+	// "#last" lines, including those above, in
+	// order. Keys are flags, for example "-S".
+	Options []Attr
+
+	// Matrix is the substitution matrix,
+	// or nil if the file does not have one.
+	Matrix *Matrix
+}
+
+// Matrix is a substitution score matrix.
+type Matrix struct {
+	// Rows holds the reference letters and
+	// Cols holds the query letters.
+	Rows []string
+	Cols []string
+
+	// Scores holds the scores indexed by
+	// row and then column.
+	Scores [][]int
+}
+
+// Score returns the score for aligning the reference letter ref to the query letter
+// query and whether both letters are in m. Letters are matched without regard to case.
+func (m *Matrix) Score(ref, query string) (int, bool) {
+	i := index(m.Rows, ref)
+	j := index(m.Cols, query)
+	if i < 0 || j < 0 {
+		return 0, false
+	}
+	return m.Scores[i][j], true
+}
+
+func index(letters []string, l string) int {
+	for i, s := range letters {
+		if strings.EqualFold(s, l) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReadParamsFile reads a last-train parameter file from the named file. The File field
+// of the returned Params is set to path.
+func ReadParamsFile(path string) (*Params, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := ReadParams(f)
+	if err != nil {
+		return nil, err
+	}
+	p.File = path
+	return p, nil
+}
+
+// ReadParams reads last-train parameters from r.
+func ReadParams(r io.Reader) (*Params, error) {
+	var (
+		p    Params
+		line int
+	)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line++
+		l := strings.TrimSpace(sc.Text())
+		switch {
+		case l == "":
+		case strings.HasPrefix(l, "#last"):
+			err := p.parseOptions(strings.Fields(l[len("#last"):]))
+			if err != nil {
+				return nil, fmt.Errorf("last: params line %d: %v", line, err)
+			}
+		case l[0] == '#':
+		default:
+			err := p.parseMatrix(strings.Fields(l))
+			if err != nil {
+				return nil, fmt.Errorf("last: params line %d: %v", line, err)
+			}
+		}
+	}
+	err := sc.Err()
+	if err != nil {
+		return nil, err
+	}
+	if p.Matrix != nil && len(p.Matrix.Rows) == 0 {
+		return nil, fmt.Errorf("last: params line %d: matrix has no rows", line)
+	}
+	return &p, nil
+}
+
+// parseOptions parses the options of a "#last" line. Option values may be attached
+// to their flag, as in -t4.4, or follow it, as in -a 21.
+func (p *Params) parseOptions(f []string) error {
+	for i := 0; i < len(f); i++ {
+		flag := f[i]
+		if len(flag) < 2 || flag[0] != '-' {
+			return fmt.Errorf("invalid option %q", flag)
+		}
+		var val string
+		if len(flag) > 2 && flag[1] != '-' {
+			flag, val = flag[:2], flag[2:]
+		} else {
+			if i+1 == len(f) {
+				return fmt.Errorf("missing value for option %s", flag)
+			}
+			i++
+			val = f[i]
+		}
+		p.Options = append(p.Options, Attr{Key: flag, Value: val})
+
+		var dst *int
+		switch flag {
+		case "-r":
+			dst = &p.MatchScore
+		case "-q":
+			dst = &p.MismatchCost
+		case "-a":
+			dst = &p.GapCost
+		case "-b":
+			dst = &p.ExtendCost
+		case "-A":
+			dst = &p.InsertCost
+		case "-B":
+			dst = &p.InsertExtend
+		case "-t":
+			t, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for option -t: %q", val)
+			}
+			p.Temperature = t
+			continue
+		default:
+			continue
+		}
+		v, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid value for option %s: %q", flag, val)
+		}
+		*dst = v
+	}
+	return nil
+}
+
+// parseMatrix parses a line of the substitution matrix. The first line holds the
+// query letters and each following line a reference letter and its scores.
+func (p *Params) parseMatrix(f []string) error {
+	if p.Matrix == nil {
+		p.Matrix = &Matrix{Cols: f}
+		return nil
+	}
+	m := p.Matrix
+	if len(f) != len(m.Cols)+1 {
+		return fmt.Errorf("matrix row has %d scores, want %d", len(f)-1, len(m.Cols))
+	}
+	row := make([]int, len(m.Cols))
+	for i, s := range f[1:] {
+		var err error
+		row[i], err = strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid score %q", s)
+		}
+	}
+	m.Rows = append(m.Rows, f[0])
+	m.Scores = append(m.Scores, row)
+	return nil
+}
+
+// Apply sets the score options of a to the trained parameters in p. If p.File is not
+// empty, ScoreFile is set to it and lastal reads the substitution matrix and any options
+// not modelled by Align from the file. Otherwise the match score and mismatch cost are
+// set, and the substitution matrix is not used. Parameters that are zero in p do not
+// change a.
+func (p *Params) Apply(a *Align) {
+	if p.File != "" {
+		a.ScoreFile = p.File
+	} else {
+		setInt(&a.MatchScore, p.MatchScore)
+		setInt(&a.MismatchCost, p.MismatchCost)
+	}
+	setInt(&a.GapCost, p.GapCost)
+	setInt(&a.ExtendCost, p.ExtendCost)
+	setInt(&a.InsertCost, p.InsertCost)
+	setInt(&a.InsertExtend, p.InsertExtend)
+	if p.Temperature != 0 {
+		a.Temperature = p.Temperature
+	}
+}
+
+func setInt(dst *int, v int) {
+	if v != 0 {
+		*dst = v
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *P) TestReadParams(c *check.C) {
+	path := filepath.Join("testdata", "train.out")
+	p, err := ReadParamsFile(path)
+	c.Assert(err, check.Equals, nil)
+	c.Check(p.File, check.Equals, path)
+	c.Check(p.GapCost, check.Equals, 15)
+	c.Check(p.ExtendCost, check.Equals, 2)
+	c.Check(p.InsertCost, check.Equals, 16)
+	c.Check(p.InsertExtend, check.Equals, 2)
+	c.Check(p.MatchScore, check.Equals, 0)
+	c.Check(p.Temperature, check.Equals, 4.5512)
+	c.Check(p.Options, check.DeepEquals, []Attr{
+		{"-t", "4.5512"}, {"-a", "15"}, {"-A", "16"}, {"-b", "2"}, {"-B", "2"}, {"-S", "1"},
+	})
+	c.Assert(p.Matrix, check.NotNil)
+	c.Check(p.Matrix.Rows, check.DeepEquals, []string{"A", "C", "G", "T"})
+	c.Check(p.Matrix.Cols, check.DeepEquals, []string{"A", "C", "G", "T"})
+	c.Check(p.Matrix.Scores[0], check.DeepEquals, []int{5, -14, -8, -17})
+	score, ok := p.Matrix.Score("g", "C")
+	c.Check(ok, check.Equals, true)
+	c.Check(score, check.Equals, -17)
+	_, ok = p.Matrix.Score("N", "A")
+	c.Check(ok, check.Equals, false)
+
+	a := Align{GapCost: 7, MinGapped: 40, DB: "db", InFiles: []string{"reads.fa"}}
+	p.Apply(&a)
+	args, err := external.Build(a)
+	c.Check(err, check.Equals, nil)
+	c.Check(args, check.DeepEquals, []string{
+		"lastal", "-p", path, "-a", "15", "-b", "2", "-A", "16", "-B", "2",
+		"-e", "40", "-t", "4.5512", "db", "reads.fa",
+	})
+
+	p, err = ReadParams(strings.NewReader("#last -r 6 -q 18 -a 21 -b 9\n"))
+	c.Assert(err, check.Equals, nil)
+	c.Check(p.Matrix, check.IsNil)
+	a = Align{}
+	p.Apply(&a)
+	c.Check(a.ScoreFile, check.Equals, "")
+	c.Check([]int{a.MatchScore, a.MismatchCost, a.GapCost, a.ExtendCost}, check.DeepEquals, []int{6, 18, 21, 9})
+
+	for _, t := range []struct {
+		in  string
+		err string
+	}{
+		{"#last -a\n", "last: params line 1: missing value for option -a"},
+		{"#last -a x\n", `last: params line 1: invalid value for option -a: "x"`},
+		{"#last a 1\n", `last: params line 1: invalid option "a"`},
+		{"  A C\nA 1\n", "last: params line 2: matrix row has 1 scores, want 2"},
+		{"  A C\nA 1 x\n", `last: params line 2: invalid score "x"`},
+		{"  A C\n", "last: params line 1: matrix has no rows"},
+	} {
+		_, err = ReadParams(strings.NewReader(t.in))
+		c.Check(err, check.ErrorMatches, t.err)
+	}
+}
+
+func (s *P) TestTrainArgs(c *check.C) {
+	args, err := external.Build(Train{
+		RevSym:       true,
+		NoPostMask:   true,
+		SampleNumber: 500,
+		Threads:      4,
+		InFormat:     Fastq,
+		DB:           "db",
+		InFiles:      []string{"reads.fq"},
+	})
+	c.Check(err, check.Equals, nil)
+	c.Check(args, check.DeepEquals, []string{
+		"last-train", "--revsym", "--postmask=0", "--sample-number=500", "-P", "4", "-Q", "1", "db", "reads.fq",
+	})
+
+	_, err = Train{DB: "db"}.BuildCommand()
+	c.Check(err, check.Equals, ErrMissingRequired)
+}
@@ -0,0 +1,153 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// OutputType is the type of output written by lastal, the -j option. The zero
+// value, MatchCounts, is not passed to lastal and so gives its default, Gapped.
+type OutputType int
+
+const (
+	MatchCounts     OutputType = iota // 0: match counts
+	Gapless                           // 1: gapless alignments
+	RedundantGapped                   // 2: redundant gapped alignments
+	Gapped                            // 3: gapped alignments
+	ColumnAmbiguity                   // 4: column ambiguity estimates
+	GammaCentroid                     // 5: gamma-centroid alignments
+	LAMA                              // 6: LAMA alignments
+)
+
+var outputTypes = [...]string{
+	MatchCounts:     "MatchCounts",
+	Gapless:         "Gapless",
+	RedundantGapped: "RedundantGapped",
+	Gapped:          "Gapped",
+	ColumnAmbiguity: "ColumnAmbiguity",
+	GammaCentroid:   "GammaCentroid",
+	LAMA:            "LAMA",
+}
+
+func (t OutputType) String() string { return enumString("OutputType", int(t), outputTypes[:]) }
+
+// MarshalText returns the lastal option value for t.
+func (t OutputType) MarshalText() ([]byte, error) {
+	return enumText("output type", int(t), len(outputTypes))
+}
+
+// UnmarshalText sets t from its name or its lastal option value.
+func (t *OutputType) UnmarshalText(text []byte) error {
+	v, err := enumParse("output type", string(text), outputTypes[:])
+	if err != nil {
+		return err
+	}
+	*t = OutputType(v)
+	return nil
+}
+
+// InFormat is the format of the sequences read by lastal, the -Q option.
+type InFormat int
+
+const (
+	FASTA         InFormat = iota // 0: fasta
+	FastqSanger                   // 1: fastq-sanger
+	FastqSolexa                   // 2: fastq-solexa
+	FastqIllumina                 // 3: fastq-illumina
+	PRB                           // 4: prb
+	PSSM                          // 5: PSSM
+
+	Fastq = FastqSanger // Fastq is the standard, Sanger, fastq format.
+)
+
+var inFormats = [...]string{
+	FASTA:         "FASTA",
+	FastqSanger:   "FastqSanger",
+	FastqSolexa:   "FastqSolexa",
+	FastqIllumina: "FastqIllumina",
+	PRB:           "PRB",
+	PSSM:          "PSSM",
+}
+
+func (f InFormat) String() string { return enumString("InFormat", int(f), inFormats[:]) }
+
+// MarshalText returns the lastal option value for f.
+func (f InFormat) MarshalText() ([]byte, error) {
+	return enumText("input format", int(f), len(inFormats))
+}
+
+// UnmarshalText sets f from its name or its lastal option value.
+func (f *InFormat) UnmarshalText(text []byte) error {
+	v, err := enumParse("input format", string(text), inFormats[:])
+	if err != nil {
+		return err
+	}
+	*f = InFormat(v)
+	return nil
+}
+
+// MaskLower specifies when lastal masks lowercase letters during extensions, the -u
+// option. The zero value, NeverMask, is not passed to lastal and so gives its default.
+type MaskLower int
+
+const (
+	NeverMask         MaskLower = iota // 0: never
+	MaskGapless                        // 1: gapless
+	MaskGaplessGapped                  // 2: gapless and gapped but not final
+	AlwaysMask                         // 3: always
+)
+
+var maskLowers = [...]string{
+	NeverMask:         "NeverMask",
+	MaskGapless:       "MaskGapless",
+	MaskGaplessGapped: "MaskGaplessGapped",
+	AlwaysMask:        "AlwaysMask",
+}
+
+func (m MaskLower) String() string { return enumString("MaskLower", int(m), maskLowers[:]) }
+
+// MarshalText returns the lastal option value for m.
+func (m MaskLower) MarshalText() ([]byte, error) {
+	return enumText("lowercase masking", int(m), len(maskLowers))
+}
+
+// UnmarshalText sets m from its name or its lastal option value.
+func (m *MaskLower) UnmarshalText(text []byte) error {
+	v, err := enumParse("lowercase masking", string(text), maskLowers[:])
+	if err != nil {
+		return err
+	}
+	*m = MaskLower(v)
+	return nil
+}
+
+func enumString(typ string, v int, names []string) string {
+	if v < 0 || v >= len(names) {
+		return fmt.Sprintf("%s(%d)", typ, v)
+	}
+	return names[v]
+}
+
+func enumParse(what, text string, names []string) (int, error) {
+	for i, n := range names {
+		if n == text {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil || v < 0 || v >= len(names) {
+		return 0, fmt.Errorf("last: invalid %s: %q", what, text)
+	}
+	return v, nil
+}
+
+func enumText(what string, v, n int) ([]byte, error) {
+	if v < 0 || v >= n {
+		return nil, fmt.Errorf("last: invalid %s: %d", what, v)
+	}
+	return []byte(strconv.Itoa(v)), nil
+}
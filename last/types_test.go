@@ -0,0 +1,49 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *P) TestEnums(c *check.C) {
+	args, err := external.Build(Align{
+		MaskLower:  MaskGapless,
+		OutputType: LAMA,
+		InFormat:   Fastq,
+		DB:         "db",
+		InFiles:    []string{"in.fq"},
+	})
+	c.Check(err, check.Equals, nil)
+	c.Check(args, check.DeepEquals, []string{"lastal", "-u", "1", "-j", "6", "-Q", "1", "db", "in.fq"})
+
+	c.Check(LAMA.String(), check.Equals, "LAMA")
+	c.Check(FastqIllumina.String(), check.Equals, "FastqIllumina")
+	c.Check(AlwaysMask.String(), check.Equals, "AlwaysMask")
+	c.Check(OutputType(7).String(), check.Equals, "OutputType(7)")
+
+	_, err = Align{OutputType: 7, DB: "db", InFiles: []string{"in.fa"}}.BuildCommand()
+	c.Check(err, check.ErrorMatches, "external: error calling MarshalText for type last.OutputType: last: invalid output type: 7")
+}
+
+func (s *P) TestUnmarshalEnums(c *check.C) {
+	var t OutputType
+	c.Check(t.UnmarshalText([]byte("GammaCentroid")), check.Equals, nil)
+	c.Check(t, check.Equals, GammaCentroid)
+	c.Check(t.UnmarshalText([]byte("6")), check.Equals, nil)
+	c.Check(t, check.Equals, LAMA)
+	c.Check(t.UnmarshalText([]byte("7")), check.ErrorMatches, `last: invalid output type: "7"`)
+	c.Check(t, check.Equals, LAMA)
+
+	var f InFormat
+	c.Check(f.UnmarshalText([]byte("FastqSanger")), check.Equals, nil)
+	c.Check(f, check.Equals, Fastq)
+	var m MaskLower
+	c.Check(m.UnmarshalText([]byte("AlwaysMask")), check.Equals, nil)
+	c.Check(m, check.Equals, AlwaysMask)
+	c.Check(m.UnmarshalText([]byte("sometimes")), check.ErrorMatches, `last: invalid lowercase masking: "sometimes"`)
+}
@@ -0,0 +1,227 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/biogo/external"
+)
+
+// ManifestSuffix is the suffix of the name of the manifest file written next to a
+// database by DB.Update.
+const ManifestSuffix = ".manifest"
+
+// Manifest records how a database was built by DB.Update.
+type Manifest struct {
+	// DB holds the field values of the DB
+	// that built the database.
+	DB DB `json:"db"`
+
+	// Inputs holds the digests of the input
+	// files of the DB, in the order they are
+	// declared.
+	Inputs []Digest `json:"inputs"`
+
+	// Files holds the names of the database
+	// files relative to its directory.
+	Files []string `json:"files"`
+}
+
+// Digest is the SHA-256 digest of an input file.
+type Digest struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// ReadManifest reads the manifest of the database name from name+ManifestSuffix.
+func ReadManifest(name string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(name + ManifestSuffix)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Update builds the database described by db with lastdb unless the manifest of an
+// existing database named db.OutFile matches db's field values and the digests of its
+// input files, and all the database files listed in the manifest exist. It returns
+// whether lastdb was run.
+//
+// The database is built under a temporary name in a new directory next to OutFile, and
+// its files are renamed into place once lastdb succeeds. The manifest is written last,
+// so a database left incomplete by a failed update is rebuilt by the next. Files listed
+// by the previous manifest that are not part of the new database are removed.
+func (db DB) Update(ctx context.Context, r external.Runner) (built bool, err error) {
+	if db.OutFile == "" || len(db.InFiles) == 0 {
+		return false, ErrMissingRequired
+	}
+	if db.OnlyCount {
+		return false, errors.New("last: cannot update database with OnlyCount set")
+	}
+	inputs, err := db.digests()
+	if err != nil {
+		return false, err
+	}
+	m := &Manifest{DB: db, Inputs: inputs}
+
+	old, err := ReadManifest(db.OutFile)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if old != nil && m.matches(old) && old.complete(filepath.Dir(db.OutFile)) {
+		return false, nil
+	}
+
+	dir, base := filepath.Split(db.OutFile)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := ioutil.TempDir(dir, "."+base+"-")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(tmp)
+
+	tdb := db
+	tdb.OutFile = filepath.Join(tmp, base)
+	cmd, err := tdb.BuildCommand()
+	if err != nil {
+		return false, err
+	}
+	err = r.For(tdb).Run(ctx, cmd)
+	if err != nil {
+		return false, err
+	}
+
+	files, err := ioutil.ReadDir(tmp)
+	if err != nil {
+		return false, err
+	}
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), base) {
+			m.Files = append(m.Files, f.Name())
+		}
+	}
+	sort.Strings(m.Files)
+	if len(m.Files) == 0 {
+		return false, errors.New("last: lastdb wrote no database files")
+	}
+
+	err = os.Remove(db.OutFile + ManifestSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	for _, f := range m.Files {
+		err = os.Rename(filepath.Join(tmp, f), filepath.Join(dir, f))
+		if err != nil {
+			return false, err
+		}
+	}
+	if old != nil {
+		keep := make(map[string]bool)
+		for _, f := range m.Files {
+			keep[f] = true
+		}
+		for _, f := range old.Files {
+			if !keep[f] && filepath.Base(f) == f {
+				err = os.Remove(filepath.Join(dir, f))
+				if err != nil && !os.IsNotExist(err) {
+					return false, err
+				}
+			}
+		}
+	}
+	return true, m.write(db.OutFile, tmp)
+}
+
+// digests returns the digests of the input files declared by db.
+func (db DB) digests() ([]Digest, error) {
+	paths, err := external.DeclaredPaths(db)
+	if err != nil {
+		return nil, err
+	}
+	d := make([]Digest, len(paths.In))
+	for i, p := range paths.In {
+		sum, err := fileDigest(p)
+		if err != nil {
+			return nil, err
+		}
+		d[i] = Digest{Path: p, SHA256: sum}
+	}
+	return d, nil
+}
+
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// matches returns whether m and o record the same field values and inputs.
+func (m *Manifest) matches(o *Manifest) bool {
+	a, err := json.Marshal(Manifest{DB: m.DB, Inputs: m.Inputs})
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(Manifest{DB: o.DB, Inputs: o.Inputs})
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(a, b)
+}
+
+// complete returns whether all the files listed in m exist in dir.
+func (m *Manifest) complete(dir string) bool {
+	if len(m.Files) == 0 {
+		return false
+	}
+	for _, f := range m.Files {
+		_, err := os.Stat(filepath.Join(dir, f))
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// write writes m as the manifest of the database name, using the directory tmp to
+// hold the new manifest until it is renamed into place.
+func (m *Manifest) write(name, tmp string) error {
+	b, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(tmp, filepath.Base(name)+ManifestSuffix)
+	err = ioutil.WriteFile(path, append(b, '\n'), 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(path, name+ManifestSuffix)
+}
@@ -0,0 +1,111 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package last
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+// fakeLastdb registers a script as the lastdb binary that writes a database with
+// the given number of volumes and logs each run to the returned file. It returns the
+// log path and a function that removes the registration.
+func fakeLastdb(c *check.C, volumes int) (string, func()) {
+	log := filepath.Join(c.MkDir(), "log")
+	return log, fakeTool(c, "lastdb", `echo run >> `+log+`
+while [ "${1#-}" != "$1" ]; do shift; done
+out=$1; shift
+cat "$@" > "$out.suf"
+echo alphabet=ACGT > "$out.prj"
+i=0
+while [ $i -lt `+strconv.Itoa(volumes)+` ]; do echo alphabet=ACGT > "$out$i.prj"; i=$((i+1)); done
+`)
+}
+
+func runs(c *check.C, log string) int {
+	b, err := ioutil.ReadFile(log)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	c.Assert(err, check.Equals, nil)
+	return strings.Count(string(b), "run")
+}
+
+func (s *P) TestUpdate(c *check.C) {
+	log, unregister := fakeLastdb(c, 2)
+	defer unregister()
+
+	dir := c.MkDir()
+	in := filepath.Join(dir, "in.fa")
+	c.Assert(ioutil.WriteFile(in, []byte(">a\nACGT\n"), 0644), check.Equals, nil)
+	db := DB{OutFile: filepath.Join(dir, "db"), InFiles: []string{in}}
+
+	built, err := db.Update(context.Background(), external.Runner{})
+	c.Assert(err, check.Equals, nil)
+	c.Check(built, check.Equals, true)
+	c.Check(runs(c, log), check.Equals, 1)
+	m, err := ReadManifest(db.OutFile)
+	c.Assert(err, check.Equals, nil)
+	c.Check(m.Files, check.DeepEquals, []string{"db.prj", "db.suf", "db0.prj", "db1.prj"})
+	c.Assert(m.Inputs, check.HasLen, 1)
+	c.Check(m.Inputs[0].SHA256, check.HasLen, 64)
+
+	// Unchanged inputs and options.
+	built, err = db.Update(context.Background(), external.Runner{})
+	c.Assert(err, check.Equals, nil)
+	c.Check(built, check.Equals, false)
+	c.Check(runs(c, log), check.Equals, 1)
+
+	// Changed options.
+	db.Softmask = true
+	built, err = db.Update(context.Background(), external.Runner{})
+	c.Assert(err, check.Equals, nil)
+	c.Check(built, check.Equals, true)
+	c.Check(runs(c, log), check.Equals, 2)
+
+	// Changed input.
+	c.Assert(ioutil.WriteFile(in, []byte(">a\nACGTT\n"), 0644), check.Equals, nil)
+	built, err = db.Update(context.Background(), external.Runner{})
+	c.Assert(err, check.Equals, nil)
+	c.Check(built, check.Equals, true)
+	c.Check(runs(c, log), check.Equals, 3)
+	suf, err := ioutil.ReadFile(db.OutFile + ".suf")
+	c.Assert(err, check.Equals, nil)
+	c.Check(string(suf), check.Equals, ">a\nACGTT\n")
+
+	// Missing database file.
+	c.Assert(os.Remove(db.OutFile+"1.prj"), check.Equals, nil)
+	built, err = db.Update(context.Background(), external.Runner{})
+	c.Assert(err, check.Equals, nil)
+	c.Check(built, check.Equals, true)
+	c.Check(runs(c, log), check.Equals, 4)
+
+	// Fewer volumes remove stale files.
+	_, unregister = fakeLastdb(c, 1)
+	defer unregister()
+	db.Softmask = false
+	built, err = db.Update(context.Background(), external.Runner{})
+	c.Assert(err, check.Equals, nil)
+	c.Check(built, check.Equals, true)
+	_, err = os.Stat(db.OutFile + "1.prj")
+	c.Check(os.IsNotExist(err), check.Equals, true)
+	names, err := filepath.Glob(filepath.Join(dir, "*"))
+	c.Assert(err, check.Equals, nil)
+	for i, n := range names {
+		names[i] = filepath.Base(n)
+	}
+	c.Check(names, check.DeepEquals, []string{"db.manifest", "db.prj", "db.suf", "db0.prj", "in.fa"})
+
+	_, err = DB{OutFile: db.OutFile, InFiles: []string{filepath.Join(dir, "missing.fa")}}.Update(context.Background(), external.Runner{})
+	c.Check(os.IsNotExist(err), check.Equals, true)
+}
@@ -0,0 +1,231 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Paths holds the input and output file paths declared by a CommandBuilder.
+type Paths struct {
+	In  []string
+	Out []string
+}
+
+// DeclaredPaths returns the file paths held in fields of cb that are tagged with a "path"
+// key. The value of the tag is "in" for input files and "out" for output files, and may
+// be followed by the ",strict" option. Tagged fields must be strings or slices or arrays
+// of strings. Fields of untagged struct fields are inspected recursively. Empty paths
+// and the standard stream name "-" are ignored.
+func DeclaredPaths(cb CommandBuilder) (Paths, error) {
+	v := reflect.ValueOf(cb)
+	if kind := v.Kind(); kind == reflect.Interface || kind == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return Paths{}, errors.New("external: not a struct")
+	}
+	var p Paths
+	err := p.collect(v)
+	return p, err
+}
+
+func (p *Paths) collect(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		tf := t.Field(i)
+		if tf.PkgPath != "" && !tf.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		tag, ok := tf.Tag.Lookup("path")
+		if !ok {
+			if fv.Kind() == reflect.Struct {
+				err := p.collect(fv)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		dir, _, err := pathTag(tag, tf.Name)
+		if err != nil {
+			return err
+		}
+		dst := &p.In
+		if dir == "out" {
+			dst = &p.Out
+		}
+		switch fv.Kind() {
+		case reflect.String:
+			*dst = appendPath(*dst, fv.String())
+		case reflect.Array, reflect.Slice:
+			if fv.Type().Elem().Kind() != reflect.String {
+				return fmt.Errorf("external: path field %s is not a string type", tf.Name)
+			}
+			for j := 0; j < fv.Len(); j++ {
+				*dst = appendPath(*dst, fv.Index(j).String())
+			}
+		default:
+			return fmt.Errorf("external: path field %s is not a string type", tf.Name)
+		}
+	}
+	return nil
+}
+
+func appendPath(paths []string, p string) []string {
+	if p == "" || p == "-" {
+		return paths
+	}
+	return append(paths, p)
+}
+
+// Launcher is implemented by types that wrap a command line for execution by a launcher
+// such as a container runtime or a resource control tool. Wrap returns the command line
+// to execute given the command line args and the paths declared by the command's builder.
+type Launcher interface {
+	Wrap(args []string, paths Paths) ([]string, error)
+}
+
+// Launch builds the command for cb and wraps it with each of the launchers in turn,
+// so the first launcher is the innermost. The standard streams, working directory and
+// environment of the built command are retained by the returned command.
+func Launch(cb CommandBuilder, launchers ...Launcher) (*exec.Cmd, error) {
+	cmd, err := cb.BuildCommand()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := DeclaredPaths(cb)
+	if err != nil {
+		return nil, err
+	}
+	// Wrap the resolved path so that the launcher
+	// does not look the tool up again on its PATH.
+	args := append([]string{cmd.Path}, cmd.Args[1:]...)
+	for _, l := range launchers {
+		args, err = l.Wrap(args, paths)
+		if err != nil {
+			return nil, err
+		}
+	}
+	wrapped, err := Command(args)
+	if err != nil {
+		return nil, err
+	}
+	wrapped.Stdin = cmd.Stdin
+	wrapped.Stdout = cmd.Stdout
+	wrapped.Stderr = cmd.Stderr
+	wrapped.Dir = cmd.Dir
+	wrapped.Env = cmd.Env
+	wrapped.ExtraFiles = cmd.ExtraFiles
+	return wrapped, nil
+}
+
+// Prefix is a Launcher that prefixes a command line with a fixed set of arguments.
+type Prefix []string
+
+// Wrap returns args prefixed with the receiver.
+func (p Prefix) Wrap(args []string, _ Paths) ([]string, error) {
+	return append(append([]string(nil), p...), args...), nil
+}
+
+// Nice returns a Prefix that runs a command with its niceness adjusted by n.
+func Nice(n int) Prefix { return Prefix{"nice", "-n", strconv.Itoa(n)} }
+
+// Taskset returns a Prefix that runs a command bound to the CPUs in the list cpus,
+// for example "0-3,8".
+func Taskset(cpus string) Prefix { return Prefix{"taskset", "-c", cpus} }
+
+// Srun returns a Prefix that runs a command as a SLURM job step with the given
+// srun options.
+func Srun(options ...string) Prefix { return append(Prefix{"srun"}, options...) }
+
+// Container is a Launcher that runs a command inside an Apptainer or Singularity
+// container image. The directories holding the paths declared by the command's builder
+// are bind mounted into the container at the same location, read-only for directories
+// holding only input files.
+type Container struct {
+	// Runtime is the container runtime. If Runtime
+	// is empty, "apptainer" is used.
+	Runtime string
+
+	// Image is the container image to run.
+	Image string
+
+	// Binds holds additional bind mount specifications
+	// in the form src[:dest[:opts]].
+	Binds []string
+
+	// Options holds additional options passed to
+	// the runtime's exec subcommand.
+	Options []string
+}
+
+// Wrap returns args wrapped in a container runtime exec command line.
+func (c Container) Wrap(args []string, paths Paths) ([]string, error) {
+	if c.Image == "" {
+		return nil, errors.New("external: no container image")
+	}
+	runtime := c.Runtime
+	if runtime == "" {
+		runtime = "apptainer"
+	}
+	binds, err := bindMounts(paths)
+	if err != nil {
+		return nil, err
+	}
+	cl := []string{runtime, "exec"}
+	for _, b := range append(binds, c.Binds...) {
+		cl = append(cl, "--bind", b)
+	}
+	cl = append(cl, c.Options...)
+	cl = append(cl, c.Image)
+	return append(cl, args...), nil
+}
+
+// bindMounts returns the bind mount specifications for the directories holding paths.
+func bindMounts(paths Paths) ([]string, error) {
+	readOnly := make(map[string]bool)
+	for _, set := range []struct {
+		paths []string
+		ro    bool
+	}{
+		{paths.In, true},
+		{paths.Out, false},
+	} {
+		for _, p := range set.paths {
+			p, err := filepath.Abs(p)
+			if err != nil {
+				return nil, err
+			}
+			if fi, err := os.Stat(p); err != nil || !fi.IsDir() {
+				p = filepath.Dir(p)
+			}
+			if ro, ok := readOnly[p]; !ok || ro {
+				readOnly[p] = set.ro
+			}
+		}
+	}
+	dirs := make([]string, 0, len(readOnly))
+	for d := range readOnly {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+	binds := make([]string, len(dirs))
+	for i, d := range dirs {
+		binds[i] = d + ":" + d
+		if readOnly[d] {
+			binds[i] += ":ro"
+		}
+	}
+	return binds, nil
+}
@@ -0,0 +1,117 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+type Cat struct {
+	Cmd     string   `buildarg:"{{if .}}{{.}}{{else}}cat{{end}}"` // cat
+	Number  bool     `buildarg:"{{if .}}-n{{end}}"`               // -n
+	Log     Log      // Not an argument.
+	InFiles []string `buildarg:"{{if .}}{{args .}}{{end}}" path:"in"` // <in>...
+}
+
+type Log struct {
+	File string `path:"out"`
+}
+
+func (c Cat) BuildCommand() (*exec.Cmd, error) {
+	return Command(Must(Build(c)))
+}
+
+func (s *S) TestDeclaredPaths(c *check.C) {
+	p, err := DeclaredPaths(Cat{Log: Log{File: "log"}, InFiles: []string{"a", "-", "", "b"}})
+	c.Check(err, check.Equals, nil)
+	c.Check(p, check.DeepEquals, Paths{In: []string{"a", "b"}, Out: []string{"log"}})
+
+	_, err = DeclaredPaths(struct {
+		Ls
+		N int `path:"in"`
+	}{})
+	c.Check(err, check.ErrorMatches, "external: path field N is not a string type")
+}
+
+func (s *S) TestWrap(c *check.C) {
+	for _, t := range []struct {
+		l    Launcher
+		args []string
+		want []string
+	}{
+		{Nice(10), []string{"cat", "a"}, []string{"nice", "-n", "10", "cat", "a"}},
+		{Taskset("0-3"), []string{"cat"}, []string{"taskset", "-c", "0-3", "cat"}},
+		{Srun("-n1", "--exclusive"), []string{"cat"}, []string{"srun", "-n1", "--exclusive", "cat"}},
+		{
+			Container{Image: "tools.sif"},
+			[]string{"cat", "/data/in/a"},
+			[]string{"apptainer", "exec", "--bind", "/data/in:/data/in:ro", "--bind", "/data/out:/data/out", "tools.sif", "cat", "/data/in/a"},
+		},
+		{
+			Container{Runtime: "singularity", Image: "tools.sif", Binds: []string{"/scratch"}, Options: []string{"--cleanenv"}},
+			[]string{"cat"},
+			[]string{"singularity", "exec", "--bind", "/data/in:/data/in:ro", "--bind", "/data/out:/data/out", "--bind", "/scratch", "--cleanenv", "tools.sif", "cat"},
+		},
+	} {
+		got, err := t.l.Wrap(t.args, Paths{In: []string{"/data/in/a", "/data/in/b"}, Out: []string{"/data/out/c"}})
+		c.Check(err, check.Equals, nil)
+		c.Check(got, check.DeepEquals, t.want)
+	}
+
+	_, err := Container{}.Wrap([]string{"cat"}, Paths{})
+	c.Check(err, check.ErrorMatches, "external: no container image")
+}
+
+func (s *S) TestLaunch(c *check.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("shell scripts not executable")
+	}
+	dir := c.MkDir()
+	launcher := fakeTool(c, dir, "fake-apptainer", `echo "$@"`)
+	in := filepath.Join(dir, "in.txt")
+	out := filepath.Join(dir, "out", "log")
+	cat, err := Resolve("cat")
+	c.Assert(err, check.Equals, nil)
+
+	cmd, err := Launch(
+		Cat{Number: true, Log: Log{File: out}, InFiles: []string{in}},
+		Container{Runtime: launcher, Image: "tools.sif"},
+		Prefix{"env", "BIOGO_TEST=1"},
+	)
+	c.Assert(err, check.Equals, nil)
+	c.Check(cmd.Args[:2], check.DeepEquals, []string{"env", "BIOGO_TEST=1"})
+	buf := &bytes.Buffer{}
+	cmd.Stdout = buf
+	c.Assert(cmd.Run(), check.Equals, nil)
+	c.Check(strings.TrimSpace(buf.String()), check.Equals, strings.Join([]string{
+		"exec",
+		"--bind", dir + ":" + dir + ":ro",
+		"--bind", filepath.Dir(out) + ":" + filepath.Dir(out),
+		"tools.sif", cat, "-n", in,
+	}, " "))
+}
+
+func (s *S) TestLaunchResolved(c *check.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("shell scripts not executable")
+	}
+	if _, err := exec.LookPath("nice"); err != nil {
+		c.Skip("nice not present")
+	}
+	const name = "biogo-launch-cat"
+	path := fakeTool(c, c.MkDir(), name, `cat "$@"`)
+	DefaultResolver.Register(name, path)
+	defer DefaultResolver.Register(name, "")
+
+	cmd, err := Launch(Cat{Cmd: name, Number: true}, Nice(10))
+	c.Assert(err, check.Equals, nil)
+	c.Check(cmd.Args, check.DeepEquals, []string{"nice", "-n", "10", path, "-n"})
+}
@@ -17,62 +17,78 @@ type Mafft struct {
 	//
 	// For details relating to options and parameters, see the MAFFT manual.
 	//
-	Cmd string `buildarg:"{{if .}}{{.}}{{else}}mafft{{end}}"` // mafft
+	Cmd string `buildarg:"{{if .}}{{.}}{{else}}mafft{{end}}" doc:"mafft"`
 
 	// Algorithm:
-	Auto          bool    `buildarg:"{{if .}}--auto{{end}}"`                     // --auto
-	HexamerPair   bool    `buildarg:"{{if .}}--6merpair{{end}}"`                 // --6merpair
-	GlobalPair    bool    `buildarg:"{{if .}}--globalpair{{end}}"`               // --globalpair
-	LocalPair     bool    `buildarg:"{{if .}}--localpair{{end}}"`                // --localpair
-	GenafPair     bool    `buildarg:"{{if .}}--genafpair{{end}}"`                // --genafpair
-	FastaPair     bool    `buildarg:"{{if .}}--fastapair{{end}}"`                // --fastapair
-	Weighting     float64 `buildarg:"{{if .}}--weighti{{split}}{{.}}{{end}}"`    // --weighti <f.>
-	ReTree        int     `buildarg:"{{if .}}--retree{{split}}{{.}}{{end}}"`     // --retree <n>
-	MaxIterate    int     `buildarg:"{{if .}}--maxiterate{{split}}{{.}}{{end}}"` // --maxiterate <n>
-	Fft           bool    `buildarg:"{{if .}}--fft{{end}}"`                      // --fft
-	NoFft         bool    `buildarg:"{{if .}}--nofft{{end}}"`                    // --nofft
-	NoScore       bool    `buildarg:"{{if .}}--noscore{{end}}"`                  // --noscore
-	MemSave       bool    `buildarg:"{{if .}}--memsave{{end}}"`                  // --memsave
-	Partree       bool    `buildarg:"{{if .}}--parttree{{end}}"`                 // --parttree
-	DPPartTree    bool    `buildarg:"{{if .}}--dpparttree{{end}}"`               // --dpparttree
-	FastaPartTree bool    `buildarg:"{{if .}}--fastaparttree{{end}}"`            // --fastaparttree
-	PartSize      int     `buildarg:"{{if .}}--partsize{{split}}{{.}}{{end}}"`   // --partsize <n>
-	GroupSize     int     `buildarg:"{{if .}}--groupsize{{split}}{{.}}{{end}}"`  // --groupsize <n>
+	Auto          bool    `buildarg:"{{if .}}--auto{{end}}" doc:"--auto"`
+	HexamerPair   bool    `buildarg:"{{if .}}--6merpair{{end}}" doc:"--6merpair"`
+	GlobalPair    bool    `buildarg:"{{if .}}--globalpair{{end}}" doc:"--globalpair"`
+	LocalPair     bool    `buildarg:"{{if .}}--localpair{{end}}" doc:"--localpair"`
+	GenafPair     bool    `buildarg:"{{if .}}--genafpair{{end}}" doc:"--genafpair"`
+	FastaPair     bool    `buildarg:"{{if .}}--fastapair{{end}}" doc:"--fastapair"`
+	Weighting     float64 `buildarg:"{{if .}}--weighti{{split}}{{.}}{{end}}" doc:"--weighti <f.>"`
+	ReTree        int     `buildarg:"{{if .}}--retree{{split}}{{.}}{{end}}" doc:"--retree <n>"`
+	MaxIterate    int     `buildarg:"{{if .}}--maxiterate{{split}}{{.}}{{end}}" doc:"--maxiterate <n>"`
+	Fft           bool    `buildarg:"{{if .}}--fft{{end}}" doc:"--fft"`
+	NoFft         bool    `buildarg:"{{if .}}--nofft{{end}}" doc:"--nofft"`
+	NoScore       bool    `buildarg:"{{if .}}--noscore{{end}}" doc:"--noscore"`
+	MemSave       bool    `buildarg:"{{if .}}--memsave{{end}}" doc:"--memsave"`
+	Partree       bool    `buildarg:"{{if .}}--parttree{{end}}" doc:"--parttree"`
+	DPPartTree    bool    `buildarg:"{{if .}}--dpparttree{{end}}" doc:"--dpparttree"`
+	FastaPartTree bool    `buildarg:"{{if .}}--fastaparttree{{end}}" doc:"--fastaparttree"`
+	PartSize      int     `buildarg:"{{if .}}--partsize{{split}}{{.}}{{end}}" requires:"Partree|DPPartTree|FastaPartTree" doc:"--partsize <n>"`
+	GroupSize     int     `buildarg:"{{if .}}--groupsize{{split}}{{.}}{{end}}" doc:"--groupsize <n>"`
 
 	// Parameter:
-	GapOpenCost          float64 `buildarg:"{{if .}}--op{{split}}{{.}}{{end}}"`       // --op <f.>
-	ExtensionCost        float64 `buildarg:"{{if .}}--ep{{split}}{{.}}{{end}}"`       // --ep <f.>
-	LocalOpenCost        float64 `buildarg:"{{if .}}--lop{{split}}{{.}}{{end}}"`      // --lop <f.>
-	LocalPairOffset      float64 `buildarg:"{{if .}}--lep{{split}}{{.}}{{end}}"`      // --lep <f.>
-	LocalExtensionCost   float64 `buildarg:"{{if .}}--lexp{{split}}{{.}}{{end}}"`     // --lexp <f.>
-	GapOpenSkipCost      float64 `buildarg:"{{if .}}--LOP{{split}}{{.}}{{end}}"`      // --LOP <f.>
-	GapExtensionSkipCost float64 `buildarg:"{{if .}}--LEXP{{split}}{{.}}{{end}}"`     // --LEXP <f.>
-	Blosum               byte    `buildarg:"{{if .}}--bl{{split}}{{.}}{{end}}"`       // --bl <n>
-	JttPAM               uint    `buildarg:"{{if .}}--jtt{{split}}{{.}}{{end}}"`      // --jtt <n>
-	TransMembranePAM     uint    `buildarg:"{{if .}}--tm{{split}}{{.}}{{end}}"`       // --tm <n>
-	AminoMatrix          string  `buildarg:"{{if .}}--aamatrix{{split}}{{.}}{{end}}"` // --aamatrix <file>
-	FModel               bool    `buildarg:"{{if .}}--fmodel{{end}}"`                 // --fmodel
+	GapOpenCost          float64 `buildarg:"{{if .}}--op{{split}}{{.}}{{end}}" doc:"--op <f.>"`
+	ExtensionCost        float64 `buildarg:"{{if .}}--ep{{split}}{{.}}{{end}}" doc:"--ep <f.>"`
+	LocalOpenCost        float64 `buildarg:"{{if .}}--lop{{split}}{{.}}{{end}}" doc:"--lop <f.>"`
+	LocalPairOffset      float64 `buildarg:"{{if .}}--lep{{split}}{{.}}{{end}}" doc:"--lep <f.>"`
+	LocalExtensionCost   float64 `buildarg:"{{if .}}--lexp{{split}}{{.}}{{end}}" doc:"--lexp <f.>"`
+	GapOpenSkipCost      float64 `buildarg:"{{if .}}--LOP{{split}}{{.}}{{end}}" doc:"--LOP <f.>"`
+	GapExtensionSkipCost float64 `buildarg:"{{if .}}--LEXP{{split}}{{.}}{{end}}" doc:"--LEXP <f.>"`
+	Blosum               byte    `buildarg:"{{if .}}--bl{{split}}{{.}}{{end}}" doc:"--bl <n>"`
+	JttPAM               uint    `buildarg:"{{if .}}--jtt{{split}}{{.}}{{end}}" doc:"--jtt <n>"`
+	TransMembranePAM     uint    `buildarg:"{{if .}}--tm{{split}}{{.}}{{end}}" doc:"--tm <n>"`
+	AminoMatrix          string  `buildarg:"{{if .}}--aamatrix{{split}}{{.}}{{end}}" path:"in" doc:"--aamatrix <file>"`
+	FModel               bool    `buildarg:"{{if .}}--fmodel{{end}}" doc:"--fmodel"`
 
 	// Output:
-	ClustalOut bool `buildarg:"{{if .}}--clustalout{{end}}"` // --clustalout
-	InputOrder bool `buildarg:"{{if .}}--inputorder{{end}}"` // --inputorder
-	Reorder    bool `buildarg:"{{if .}}--reorder{{end}}"`    // --reorder
-	TreeOut    bool `buildarg:"{{if .}}--treeout{{end}}"`    // --treeout
-	Quiet      bool `buildarg:"{{if .}}--quiet{{end}}"`      // --quiet
+	ClustalOut bool `buildarg:"{{if .}}--clustalout{{end}}" doc:"--clustalout"`
+	InputOrder bool `buildarg:"{{if .}}--inputorder{{end}}" doc:"--inputorder"`
+	Reorder    bool `buildarg:"{{if .}}--reorder{{end}}" doc:"--reorder"`
+	TreeOut    bool `buildarg:"{{if .}}--treeout{{end}}" doc:"--treeout"`
+	Quiet      bool `buildarg:"{{if .}}--quiet{{end}}" doc:"--quiet"`
 
 	// Input:
-	Nucleic bool     `buildarg:"{{if .}}--nuc{{end}}"`                                 // --nuc
-	Amino   bool     `buildarg:"{{if .}}--amino{{end}}"`                               // --amino
-	Seed    []string `buildarg:"{{if .}}{{mprintf \"--seed\x00%s\" . | args}}{{end}}"` // --seed <file>...
+	Nucleic bool     `buildarg:"{{if .}}--nuc{{end}}" doc:"--nuc"`
+	Amino   bool     `buildarg:"{{if .}}--amino{{end}}" doc:"--amino"`
+	Seed    []string `buildarg:"{{if .}}{{mprintf \"--seed\x00%s\" . | args}}{{end}}" path:"in" doc:"--seed <file>..."`
 
 	// Performance:
-	Threads int `buildarg:"{{if .}}--thread{{split}}{{.}}{{end}}"` // --thread <n>
+	Threads int `buildarg:"{{if .}}--thread{{split}}{{.}}{{end}}" doc:"--thread <n>"`
+
+	// Extra arguments:
+	Extra []string `extra:"" doc:"arguments not modelled by other fields"`
 
 	// Files:
-	InFile string `buildarg:"{{if .}}{{.}}{{else}}-{{end}}"` // <inputfile> - default to Stdin.
+	InFile string `buildarg:"{{if .}}{{.}}{{else}}-{{end}}" path:"in" order:"1" doc:"<inputfile> - default to Stdin."`
+}
+
+// Resources returns the resources required by the command built by m. The number
+// of CPUs is given by m.Threads if it is positive.
+func (m Mafft) Resources() external.Resources {
+	var r external.Resources
+	if m.Threads > 0 {
+		r.CPUs = m.Threads
+	}
+	return r
 }
 
 func (m Mafft) BuildCommand() (*exec.Cmd, error) {
-	cl := external.Must(external.Build(m))
-	return exec.Command(cl[0], cl[1:]...), nil
+	cl, err := external.Build(m)
+	if err != nil {
+		return nil, err
+	}
+	return external.Command(cl)
 }
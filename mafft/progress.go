@@ -0,0 +1,84 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mafft
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/biogo/external"
+)
+
+var (
+	nseqLine    = regexp.MustCompile(`^nseq\s*=\s*(\d+)`)
+	countLine   = regexp.MustCompile(`^(?:STEP)?\s*(\d+)\s*/\s*(\d+)`)
+	segmentLine = regexp.MustCompile(`^Segment\s+(\d+)\s*/\s*(\d+)`)
+	refineLine  = regexp.MustCompile(`^STEP\s+\d+-\d+-\d+`)
+)
+
+// progress is a ProgressParser for the messages MAFFT writes to standard error.
+type progress struct {
+	stage string
+}
+
+// ProgressParser returns a new parser for the progress messages written by MAFFT.
+// MAFFT reports the number of sequences, the start of each stage such as
+// "Making a distance matrix" and "Progressive alignment", counts of the form
+// "STEP x / y" within a stage, and the segments processed during iterative
+// refinement.
+func (m Mafft) ProgressParser() external.ProgressParser { return &progress{} }
+
+func (p *progress) ParseProgress(line string) (external.Progress, bool) {
+	l := strings.TrimSpace(line)
+	switch {
+	case l == "":
+		return external.Progress{}, false
+	case nseqLine.MatchString(l):
+		p.stage = "Reading input"
+		return external.Progress{Stage: p.stage, Fraction: 1, Line: line}, true
+	case strings.HasSuffix(l, ".."):
+		p.stage = strings.TrimSpace(strings.TrimRight(l, "."))
+		return external.Progress{Stage: p.stage, Fraction: 0, Line: line}, true
+	case l == "done." || l == "done" || l == "Converged.":
+		if p.stage == "" {
+			return external.Progress{}, false
+		}
+		return external.Progress{Stage: p.stage, Fraction: 1, Line: line}, true
+	}
+	if m := segmentLine.FindStringSubmatch(l); m != nil {
+		p.stage = "Iterative refinement"
+		return external.Progress{Stage: p.stage, Fraction: fraction(m[1], m[2], 1), Line: line}, true
+	}
+	if refineLine.MatchString(l) {
+		p.stage = "Iterative refinement"
+		return external.Progress{Stage: p.stage, Fraction: -1, Line: line}, true
+	}
+	if m := countLine.FindStringSubmatch(l); m != nil && p.stage != "" {
+		return external.Progress{Stage: p.stage, Fraction: fraction(m[1], m[2], 0), Line: line}, true
+	}
+	return external.Progress{}, false
+}
+
+// fraction returns (n-offset)/d for the decimal strings n and d, clamped
+// to [0, 1], or -1 if the fraction cannot be calculated.
+func fraction(n, d string, offset int) float64 {
+	i, err := strconv.Atoi(n)
+	if err != nil {
+		return -1
+	}
+	j, err := strconv.Atoi(d)
+	if err != nil || j == 0 {
+		return -1
+	}
+	f := float64(i-offset) / float64(j)
+	switch {
+	case f < 0:
+		return 0
+	case f > 1:
+		return 1
+	}
+	return f
+}
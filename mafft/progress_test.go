@@ -0,0 +1,66 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mafft
+
+import (
+	"strings"
+
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+// P is the suite of tests that do not require mafft.
+type P struct{}
+
+var _ = check.Suite(&P{})
+
+func (s *P) TestProgress(c *check.C) {
+	stderr := "\n" +
+		"nseq =  3\n" +
+		"distance =  ktuples\n" +
+		"generating 200PAM scoring matrix for nucleotides ... done\n" +
+		"Making a distance matrix ..\n" +
+		"\r    1 / 3\n" +
+		"done.\n" +
+		"\n" +
+		"Constructing a UPGMA tree ... \n" +
+		"\r    0 / 3\n" +
+		"done.\n" +
+		"\n" +
+		"Progressive alignment ... \n" +
+		"\rSTEP     1 / 2 f\rSTEP     2 / 2 f\n" +
+		"done.\n" +
+		"Segment   2/  4  280- 515\n" +
+		"STEP 001-001-0  identical.\r\n" +
+		"Converged.\n"
+
+	var got []external.Progress
+	p := Mafft{}.ProgressParser()
+	w := external.NewLineWriter(func(l string) {
+		if e, ok := p.ParseProgress(l); ok {
+			e.Line = strings.TrimSpace(e.Line)
+			got = append(got, e)
+		}
+	})
+	w.Write([]byte(stderr))
+	w.Flush()
+	c.Check(got, check.DeepEquals, []external.Progress{
+		{Stage: "Reading input", Fraction: 1, Line: "nseq =  3"},
+		{Stage: "Making a distance matrix", Fraction: 0, Line: "Making a distance matrix .."},
+		{Stage: "Making a distance matrix", Fraction: 1.0 / 3, Line: "1 / 3"},
+		{Stage: "Making a distance matrix", Fraction: 1, Line: "done."},
+		{Stage: "Constructing a UPGMA tree", Fraction: 0, Line: "Constructing a UPGMA tree ..."},
+		{Stage: "Constructing a UPGMA tree", Fraction: 0, Line: "0 / 3"},
+		{Stage: "Constructing a UPGMA tree", Fraction: 1, Line: "done."},
+		{Stage: "Progressive alignment", Fraction: 0, Line: "Progressive alignment ..."},
+		{Stage: "Progressive alignment", Fraction: 0.5, Line: "STEP     1 / 2 f"},
+		{Stage: "Progressive alignment", Fraction: 1, Line: "STEP     2 / 2 f"},
+		{Stage: "Progressive alignment", Fraction: 1, Line: "done."},
+		{Stage: "Iterative refinement", Fraction: 0.25, Line: "Segment   2/  4  280- 515"},
+		{Stage: "Iterative refinement", Fraction: -1, Line: "STEP 001-001-0  identical."},
+		{Stage: "Iterative refinement", Fraction: 1, Line: "Converged."},
+	})
+}
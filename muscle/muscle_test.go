@@ -155,3 +155,8 @@ CTTGATTTGTAAACTTTCACTTAAAGCACAATAAAAATTATTTTTTAAAAATTTGCC
 		c.Check(bErr.String(), check.Equals, t.err)
 	}
 }
+
+func (s *S) TestTimeout(c *check.C) {
+	c.Check(Muscle{}.Timeout(), check.Equals, time.Duration(0))
+	c.Check(Muscle{MaxDuration: time.Hour}.Timeout(), check.Equals, time.Hour+time.Minute)
+}
@@ -0,0 +1,39 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package muscle
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/biogo/external"
+)
+
+// iterLine matches MUSCLE progress lines of the form:
+//
+//	00:00:00      9 MB(-1%)  Iter   1  100.00%  K-mer dist pass 1
+var iterLine = regexp.MustCompile(`Iter\s+(\d+)\s+([0-9.]+)%\s+(.*\S)`)
+
+// progress is a ProgressParser for the messages MUSCLE writes to standard error.
+type progress struct{}
+
+// ProgressParser returns a new parser for the progress messages written by MUSCLE.
+// The stage of each event is the iteration number and the description of the step
+// being performed.
+func (m Muscle) ProgressParser() external.ProgressParser { return progress{} }
+
+func (progress) ParseProgress(line string) (external.Progress, bool) {
+	m := iterLine.FindStringSubmatch(line)
+	if m == nil {
+		return external.Progress{}, false
+	}
+	f, err := strconv.ParseFloat(m[2], 64)
+	if err != nil {
+		f = -1
+	} else {
+		f /= 100
+	}
+	return external.Progress{Stage: "Iter " + m[1] + ": " + m[3], Fraction: f, Line: line}, true
+}
@@ -0,0 +1,45 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package muscle
+
+import (
+	"github.com/biogo/external"
+
+	"gopkg.in/check.v1"
+)
+
+// P is the suite of tests that do not require muscle.
+type P struct{}
+
+var _ = check.Suite(&P{})
+
+func (s *P) TestProgress(c *check.C) {
+	p := Muscle{}.ProgressParser()
+	for _, t := range []struct {
+		line string
+		ok   bool
+		want external.Progress
+	}{
+		{line: "MUSCLE v3.8.31 by Robert C. Edgar"},
+		{line: "in.fa 3 seqs, max length 433, avg  length 421"},
+		{
+			line: "00:00:00      9 MB(-1%)  Iter   1   33.33%  K-mer dist pass 1",
+			ok:   true,
+			want: external.Progress{Stage: "Iter 1: K-mer dist pass 1", Fraction: 0.3333},
+		},
+		{
+			line: "00:00:01     11 MB(-1%)  Iter   3  100.00%  Refine biparts",
+			ok:   true,
+			want: external.Progress{Stage: "Iter 3: Refine biparts", Fraction: 1},
+		},
+	} {
+		got, ok := p.ParseProgress(t.line)
+		c.Check(ok, check.Equals, t.ok)
+		if ok {
+			t.want.Line = t.line
+		}
+		c.Check(got, check.DeepEquals, t.want)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// Pipeline is a sequence of commands with the standard output of each command
+// connected to the standard input of the next.
+type Pipeline struct {
+	// Cmds holds the commands of the pipeline. The
+	// standard input of the first command and the
+	// standard output of the last may be set before
+	// the pipeline is started.
+	Cmds []*exec.Cmd
+
+	// pipes holds the parent's ends of the
+	// pipes between commands.
+	pipes []*os.File
+}
+
+// Pipe returns a Pipeline of the commands built by cbs. The standard output of each
+// command other than the last must not already be set, and the standard input of each
+// command other than the first must not already be set.
+func Pipe(cbs ...CommandBuilder) (*Pipeline, error) {
+	if len(cbs) == 0 {
+		return nil, errors.New("external: empty pipeline")
+	}
+	p := &Pipeline{}
+	for _, cb := range cbs {
+		cmd, err := cb.BuildCommand()
+		if err != nil {
+			p.close()
+			return nil, err
+		}
+		if n := len(p.Cmds); n != 0 {
+			prev := p.Cmds[n-1]
+			if prev.Stdout != nil || cmd.Stdin != nil {
+				p.close()
+				return nil, errors.New("external: pipeline stream already set")
+			}
+			r, w, err := os.Pipe()
+			if err != nil {
+				p.close()
+				return nil, err
+			}
+			prev.Stdout = w
+			cmd.Stdin = r
+			p.pipes = append(p.pipes, r, w)
+		}
+		p.Cmds = append(p.Cmds, cmd)
+	}
+	return p, nil
+}
+
+// Start starts all the commands of the pipeline. If a command fails to start, the
+// commands that have been started are killed and waited for.
+func (p *Pipeline) Start() error {
+	for i, cmd := range p.Cmds {
+		err := cmd.Start()
+		if err != nil {
+			p.close()
+			for _, started := range p.Cmds[:i] {
+				started.Process.Kill()
+				started.Wait()
+			}
+			return err
+		}
+	}
+	p.close()
+	return nil
+}
+
+// Wait waits for all the commands of the pipeline to exit and returns the error of
+// the first command in pipeline order that failed.
+func (p *Pipeline) Wait() error {
+	var first error
+	for _, cmd := range p.Cmds {
+		err := cmd.Wait()
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Run starts the pipeline and waits for it to complete.
+func (p *Pipeline) Run() error {
+	err := p.Start()
+	if err != nil {
+		return err
+	}
+	return p.Wait()
+}
+
+// close closes the parent's ends of the pipes between commands.
+func (p *Pipeline) close() {
+	for _, f := range p.pipes {
+		f.Close()
+	}
+	p.pipes = nil
+}
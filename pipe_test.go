@@ -0,0 +1,48 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package external
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+type Tr struct {
+	Cmd  string `buildarg:"{{if .}}{{.}}{{else}}tr{{end}}"` // tr
+	From string `pos:""`
+	To   string `pos:""`
+}
+
+func (t Tr) BuildCommand() (*exec.Cmd, error) {
+	return Command(Must(Build(t)))
+}
+
+func (s *S) TestPipe(c *check.C) {
+	p, err := Pipe(Cat{}, Tr{From: "a-z", To: "A-Z"}, Cat{Number: true})
+	c.Assert(err, check.Equals, nil)
+	c.Assert(p.Cmds, check.HasLen, 3)
+	var out bytes.Buffer
+	p.Cmds[0].Stdin = strings.NewReader("one\ntwo\n")
+	p.Cmds[2].Stdout = &out
+	c.Check(p.Run(), check.Equals, nil)
+	c.Check(out.String(), check.Equals, "     1\tONE\n     2\tTWO\n")
+
+	p, err = Pipe(Cat{}, Ls{Cmd: "false"}, Cat{})
+	c.Assert(err, check.Equals, nil)
+	c.Check(p.Run(), check.ErrorMatches, "exit status 1")
+
+	p, err = Pipe(Cat{}, Ls{Cmd: "/nonexistent/tool"})
+	c.Assert(err, check.Equals, nil)
+	c.Check(p.Run(), check.NotNil)
+
+	_, err = Pipe()
+	c.Check(err, check.ErrorMatches, "external: empty pipeline")
+}
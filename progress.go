@@ -0,0 +1,89 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import "sync"
+
+// Progress is a progress event reported by an external tool.
+type Progress struct {
+	// Stage is a description of the current stage of the run.
+	Stage string
+
+	// Fraction is the fraction of the stage that has been
+	// completed, or -1 if it is not known.
+	Fraction float64
+
+	// Line is the line of output that gave rise to the event.
+	Line string
+}
+
+// ProgressParser is implemented by types that interpret the progress messages written
+// by a tool. ParseProgress is called with each line written to standard error and returns
+// the corresponding event and true if the line is recognised.
+type ProgressParser interface {
+	ParseProgress(line string) (p Progress, ok bool)
+}
+
+// Progresser is implemented by CommandBuilders that can interpret the progress messages
+// written by the commands they build. ProgressParser must return a new parser for each call.
+type Progresser interface {
+	ProgressParser() ProgressParser
+}
+
+// LineWriter is an io.Writer that calls a function for each line written to it. Lines
+// may be terminated by "\n", "\r\n" or a bare "\r", as used by tools that overwrite
+// progress messages on a terminal. Line terminators are not passed to the function.
+// A LineWriter is safe for concurrent use.
+type LineWriter struct {
+	fn func(line string)
+
+	mu  sync.Mutex
+	buf []byte
+	cr  bool
+}
+
+// NewLineWriter returns a LineWriter that calls fn for each line.
+func NewLineWriter(fn func(line string)) *LineWriter {
+	return &LineWriter{fn: fn}
+}
+
+// Write calls the receiver's function for each complete line in p, retaining any
+// incomplete line until it is completed by a subsequent call to Write or by Flush.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, b := range p {
+		switch b {
+		case '\n':
+			if !w.cr {
+				w.emit()
+			}
+			w.cr = false
+		case '\r':
+			if len(w.buf) != 0 {
+				w.emit()
+			}
+			w.cr = true
+		default:
+			w.buf = append(w.buf, b)
+			w.cr = false
+		}
+	}
+	return len(p), nil
+}
+
+// Flush passes any incomplete line to the receiver's function.
+func (w *LineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) != 0 {
+		w.emit()
+	}
+}
+
+func (w *LineWriter) emit() {
+	w.fn(string(w.buf))
+	w.buf = w.buf[:0]
+}
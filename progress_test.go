@@ -0,0 +1,82 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+func (s *S) TestLineWriter(c *check.C) {
+	for _, t := range []struct {
+		writes []string
+		lines  []string
+	}{
+		{
+			writes: []string{"a\nb\n"},
+			lines:  []string{"a", "b"},
+		},
+		{
+			writes: []string{"a", "b\n", "c"},
+			lines:  []string{"ab", "c"},
+		},
+		{
+			writes: []string{"\n\nx\n"},
+			lines:  []string{"", "", "x"},
+		},
+		{
+			writes: []string{"\rSTEP     1 / 2 f\rSTEP     2 / 2 f\n", "done.\n"},
+			lines:  []string{"STEP     1 / 2 f", "STEP     2 / 2 f", "done."},
+		},
+		{
+			writes: []string{"STEP 001-001-0  identical.\r", "\nConverged.\r\n"},
+			lines:  []string{"STEP 001-001-0  identical.", "Converged."},
+		},
+	} {
+		var got []string
+		w := NewLineWriter(func(l string) { got = append(got, l) })
+		for _, s := range t.writes {
+			n, err := w.Write([]byte(s))
+			c.Check(n, check.Equals, len(s))
+			c.Check(err, check.Equals, nil)
+		}
+		w.Flush()
+		c.Check(got, check.DeepEquals, t.lines)
+	}
+}
+
+type upper struct{}
+
+func (upper) ParseProgress(l string) (Progress, bool) {
+	if strings.ToUpper(l) != l {
+		return Progress{}, false
+	}
+	return Progress{Stage: l, Fraction: -1, Line: l}, true
+}
+
+func (s *S) TestRunnerStderr(c *check.C) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		c.Skip("sh not present")
+	}
+	var (
+		lines    []string
+		progress []Progress
+	)
+	r := Runner{
+		Stderr:   func(l string) { lines = append(lines, l) },
+		Progress: func(p Progress) { progress = append(progress, p) },
+		Parser:   upper{},
+	}
+	cmd := exec.Command("sh", "-c", `printf 'one\nTWO\rthree\nFOUR' >&2`)
+	c.Check(r.Run(context.Background(), cmd), check.Equals, nil)
+	c.Check(lines, check.DeepEquals, []string{"one", "TWO", "three", "FOUR"})
+	c.Check(progress, check.DeepEquals, []Progress{
+		{Stage: "TWO", Fraction: -1, Line: "TWO"},
+		{Stage: "FOUR", Fraction: -1, Line: "FOUR"},
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when a tool cannot be found in a Resolver's search path
+// or in the directories named by the PATH environment variable.
+var ErrNotFound = errors.New("external: executable not found")
+
+// DefaultResolver is the Resolver used by Command and Resolve.
+var DefaultResolver = &Resolver{}
+
+// Resolver locates the binaries for external tools. A tool name is resolved by
+// checking, in order:
+//
+//   - an override registered with the Register method,
+//   - an override in the environment variable named by EnvName,
+//   - the directories of the Resolver's search path,
+//   - the directories named by the PATH environment variable.
+//
+// Names and overrides containing a path separator are not looked up, but are resolved
+// relative to the working directory. Overrides without a path separator are looked up
+// in the directories named by PATH. The zero value of a Resolver is ready to use and is
+// safe for concurrent use.
+type Resolver struct {
+	mu        sync.RWMutex
+	overrides map[string]string
+	path      []string
+}
+
+// Register registers path as the binary for the tool name. If path is empty, any
+// existing registration for name is removed.
+func (r *Resolver) Register(name, path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if path == "" {
+		delete(r.overrides, name)
+		return
+	}
+	if r.overrides == nil {
+		r.overrides = make(map[string]string)
+	}
+	r.overrides[name] = path
+}
+
+// SetSearchPath sets the directories that are searched for tools before the
+// directories named by the PATH environment variable.
+func (r *Resolver) SetSearchPath(dirs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.path = append([]string(nil), dirs...)
+}
+
+// EnvName returns the name of the environment variable that overrides the binary
+// for the tool name. The variable name is the name of the tool in upper case with
+// characters other than letters and digits replaced with underscores and prefixed
+// with "BIOGO_", for example BIOGO_MAFFT or BIOGO_LAST_SPLIT.
+func EnvName(name string) string {
+	return "BIOGO_" + strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return r - 'a' + 'A'
+		case 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// Resolve returns the absolute path of the binary for the tool name. If the tool
+// cannot be found in the search path or PATH, the error returned wraps ErrNotFound.
+func (r *Resolver) Resolve(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("external: empty tool name")
+	}
+	if hasSeparator(name) {
+		return executable(name)
+	}
+
+	r.mu.RLock()
+	path, ok := r.overrides[name]
+	dirs := r.path
+	r.mu.RUnlock()
+	if ok {
+		return override(path)
+	}
+	if path := os.Getenv(EnvName(name)); path != "" {
+		return override(path)
+	}
+	for _, dir := range dirs {
+		path, err := executable(filepath.Join(dir, name))
+		if err == nil {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return filepath.Abs(path)
+}
+
+// hasSeparator returns whether path contains a path separator.
+func hasSeparator(path string) bool {
+	return strings.ContainsRune(path, filepath.Separator) || strings.ContainsRune(path, '/')
+}
+
+// override returns the absolute path of the executable named by an override. Values
+// without a path separator are looked up in the directories named by the PATH
+// environment variable.
+func override(path string) (string, error) {
+	if hasSeparator(path) {
+		return executable(path)
+	}
+	found, err := exec.LookPath(path)
+	if err != nil {
+		return "", fmt.Errorf("external: %v", err)
+	}
+	return executable(found)
+}
+
+// executable returns the absolute path of the executable at path.
+func executable(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("external: %v", err)
+	}
+	if !fi.Mode().IsRegular() || (runtime.GOOS != "windows" && fi.Mode()&0111 == 0) {
+		return "", fmt.Errorf("external: %s is not an executable file", path)
+	}
+	return path, nil
+}
+
+// Resolve returns the absolute path of the binary for the tool name using the
+// DefaultResolver.
+func Resolve(name string) (string, error) { return DefaultResolver.Resolve(name) }
+
+// Command returns an *exec.Cmd to execute the command line args, with the binary
+// resolved by DefaultResolver. The Args field of the returned command is args, so
+// the tool sees its name as given. If the tool cannot be found, Command behaves as
+// exec.Command and the failure is reported when the command is started. Other
+// resolution failures, such as an override that does not name an executable file,
+// are returned as errors.
+func Command(args []string) (*exec.Cmd, error) {
+	if len(args) == 0 {
+		return nil, errors.New("external: empty command line")
+	}
+	path, err := Resolve(args[0])
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return exec.Command(args[0], args[1:]...), nil
+		}
+		return nil, err
+	}
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Args[0] = args[0]
+	return cmd, nil
+}
@@ -0,0 +1,136 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"gopkg.in/check.v1"
+)
+
+// fakeTool writes an executable shell script named name in dir
+// and returns its path.
+func fakeTool(c *check.C, dir, name, script string) string {
+	path := filepath.Join(dir, name)
+	err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755)
+	c.Assert(err, check.Equals, nil)
+	return path
+}
+
+func (s *S) TestEnvName(c *check.C) {
+	for _, t := range []struct{ name, env string }{
+		{"mafft", "BIOGO_MAFFT"},
+		{"lastal", "BIOGO_LASTAL"},
+		{"last-split", "BIOGO_LAST_SPLIT"},
+		{"maf-convert.py", "BIOGO_MAF_CONVERT_PY"},
+	} {
+		c.Check(EnvName(t.name), check.Equals, t.env)
+	}
+}
+
+func (s *S) TestResolve(c *check.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("shell scripts not executable")
+	}
+	var (
+		override = c.MkDir()
+		env      = c.MkDir()
+		search   = c.MkDir()
+	)
+	const name = "biogo-fake-tool"
+	fakeTool(c, search, name, "")
+	fakeTool(c, env, name, "")
+	fakeTool(c, override, name, "")
+	ioutil.WriteFile(filepath.Join(search, "biogo-not-exec"), nil, 0644)
+
+	var r Resolver
+	_, err := r.Resolve(name)
+	c.Check(errors.Is(err, ErrNotFound), check.Equals, true)
+
+	r.SetSearchPath(c.MkDir(), search)
+	path, err := r.Resolve(name)
+	c.Check(err, check.Equals, nil)
+	c.Check(path, check.Equals, filepath.Join(search, name))
+
+	_, err = r.Resolve("biogo-not-exec")
+	c.Check(errors.Is(err, ErrNotFound), check.Equals, true)
+
+	os.Setenv(EnvName(name), filepath.Join(env, name))
+	defer os.Unsetenv(EnvName(name))
+	path, err = r.Resolve(name)
+	c.Check(err, check.Equals, nil)
+	c.Check(path, check.Equals, filepath.Join(env, name))
+
+	r.Register(name, filepath.Join(override, name))
+	path, err = r.Resolve(name)
+	c.Check(err, check.Equals, nil)
+	c.Check(path, check.Equals, filepath.Join(override, name))
+
+	r.Register(name, filepath.Join(override, "missing"))
+	_, err = r.Resolve(name)
+	c.Check(err, check.Not(check.Equals), nil)
+	c.Check(errors.Is(err, ErrNotFound), check.Equals, false)
+
+	r.Register(name, "")
+	path, err = r.Resolve(name)
+	c.Check(err, check.Equals, nil)
+	c.Check(path, check.Equals, filepath.Join(env, name))
+
+	// Overrides without a path separator are looked
+	// up in PATH, not relative to the working directory.
+	bin := c.MkDir()
+	fakeTool(c, bin, name+"-1500", "")
+	defer func(path string) { os.Setenv("PATH", path) }(os.Getenv("PATH"))
+	os.Setenv("PATH", bin)
+	os.Setenv(EnvName(name), name+"-1500")
+	path, err = r.Resolve(name)
+	c.Check(err, check.Equals, nil)
+	c.Check(path, check.Equals, filepath.Join(bin, name+"-1500"))
+	r.Register(name, name+"-1500")
+	path, err = r.Resolve(name)
+	c.Check(err, check.Equals, nil)
+	c.Check(path, check.Equals, filepath.Join(bin, name+"-1500"))
+	r.Register(name, name+"-missing")
+	_, err = r.Resolve(name)
+	c.Check(err, check.Not(check.Equals), nil)
+	r.Register(name, "")
+	os.Setenv(EnvName(name), filepath.Join(env, name))
+
+	path, err = r.Resolve(filepath.Join(search, name))
+	c.Check(err, check.Equals, nil)
+	c.Check(path, check.Equals, filepath.Join(search, name))
+}
+
+func (s *S) TestCommand(c *check.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("shell scripts not executable")
+	}
+	dir := c.MkDir()
+	const name = "biogo-fake-ls"
+	path := fakeTool(c, dir, name, `echo "$@"`)
+	DefaultResolver.Register(name, path)
+	defer DefaultResolver.Register(name, "")
+
+	cmd, err := Command([]string{name, "-l", "file"})
+	c.Assert(err, check.Equals, nil)
+	c.Check(cmd.Path, check.Equals, path)
+	c.Check(cmd.Args, check.DeepEquals, []string{name, "-l", "file"})
+	out, err := cmd.Output()
+	c.Check(err, check.Equals, nil)
+	c.Check(string(out), check.Equals, "-l file\n")
+
+	cmd, err = Command([]string{"biogo-no-such-tool"})
+	c.Check(err, check.Equals, nil)
+	c.Check(cmd.Run(), check.FitsTypeOf, &exec.Error{})
+
+	DefaultResolver.Register(name, filepath.Join(dir, "missing"))
+	_, err = Command([]string{name})
+	c.Check(err, check.Not(check.Equals), nil)
+}
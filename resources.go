@@ -0,0 +1,22 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+// Resources describes the compute resources required to run a command.
+type Resources struct {
+	// CPUs is the number of CPUs used by the
+	// command. Zero indicates no requirement.
+	CPUs int
+
+	// Memory is the amount of memory in bytes used
+	// by the command. Zero indicates no requirement.
+	Memory int64
+}
+
+// ResourceHinter is implemented by CommandBuilders that can estimate the resources
+// required by the commands they build.
+type ResourceHinter interface {
+	Resources() Resources
+}
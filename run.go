@@ -0,0 +1,149 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"time"
+)
+
+// ErrTimeout is returned by Runner.Run when a command is terminated because it exceeded
+// its time limit.
+var ErrTimeout = errors.New("external: command timed out")
+
+// DefaultGrace is the time allowed between the termination signal and the kill signal
+// if a Runner's Grace is zero.
+const DefaultGrace = 10 * time.Second
+
+// Timeouter is implemented by CommandBuilders that specify a hard wall-clock limit on the
+// run time of the commands they build. A zero duration indicates no limit.
+type Timeouter interface {
+	Timeout() time.Duration
+}
+
+// Runner runs commands in their own process group so that helper processes started by
+// the command, for example the binaries started by the mafft shell script, are terminated
+// along with the command.
+//
+// When a command exceeds its time limit or the run's context is cancelled, the process
+// group is sent SIGTERM and, if it has not exited within the grace period, SIGKILL. On
+// platforms without process groups only the direct child is signalled.
+type Runner struct {
+	// Timeout is the hard wall-clock limit on the run time
+	// of a command. A zero Timeout indicates no limit.
+	Timeout time.Duration
+
+	// Grace is the time allowed between termination and kill
+	// signals. If Grace is zero, DefaultGrace is used.
+	Grace time.Duration
+
+	// Stderr, if not nil, is called with each line written
+	// to the command's standard error as it arrives.
+	Stderr func(line string)
+
+	// Progress, if not nil, is called with each progress
+	// event that Parser recognises in standard error.
+	Progress func(Progress)
+
+	// Parser interprets standard error lines for Progress.
+	Parser ProgressParser
+}
+
+// For returns a copy of r configured for commands built by cb. If cb is a Timeouter
+// specifying a limit shorter than r.Timeout, or r.Timeout is zero, the returned Runner
+// uses the limit specified by cb. If r.Parser is nil and cb is a Progresser, the
+// returned Runner uses a new parser obtained from cb.
+func (r Runner) For(cb CommandBuilder) Runner {
+	if t, ok := cb.(Timeouter); ok {
+		if d := t.Timeout(); d > 0 && (r.Timeout == 0 || d < r.Timeout) {
+			r.Timeout = d
+		}
+	}
+	if p, ok := cb.(Progresser); ok && r.Parser == nil {
+		r.Parser = p.ProgressParser()
+	}
+	return r
+}
+
+// lines returns a LineWriter that passes lines to r.Stderr and progress
+// events to r.Progress, or nil if neither is required.
+func (r Runner) lines() *LineWriter {
+	progress := r.Progress != nil && r.Parser != nil
+	if r.Stderr == nil && !progress {
+		return nil
+	}
+	return NewLineWriter(func(line string) {
+		if r.Stderr != nil {
+			r.Stderr(line)
+		}
+		if progress {
+			if p, ok := r.Parser.ParseProgress(line); ok {
+				r.Progress(p)
+			}
+		}
+	})
+}
+
+// Run starts cmd in a new process group and waits for it to complete. If the command
+// exceeds r.Timeout, the process group is terminated and ErrTimeout is returned. If ctx
+// is cancelled before the command completes, the process group is terminated and the
+// context's error is returned.
+//
+// If r.Stderr or r.Progress are set, they are called from a separate goroutine
+// as lines are written to the command's standard error. Any existing cmd.Stderr
+// continues to receive the command's standard error.
+func (r Runner) Run(ctx context.Context, cmd *exec.Cmd) error {
+	if lw := r.lines(); lw != nil {
+		if cmd.Stderr != nil {
+			cmd.Stderr = io.MultiWriter(cmd.Stderr, lw)
+		} else {
+			cmd.Stderr = lw
+		}
+		defer lw.Flush()
+	}
+
+	setProcessGroup(cmd)
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var timeout <-chan time.Time
+	if r.Timeout > 0 {
+		t := time.NewTimer(r.Timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	select {
+	case err = <-done:
+		return err
+	case <-timeout:
+		err = ErrTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	grace := r.Grace
+	if grace == 0 {
+		grace = DefaultGrace
+	}
+	terminate(cmd)
+	t := time.NewTimer(grace)
+	defer t.Stop()
+	select {
+	case <-done:
+	case <-t.C:
+		kill(cmd)
+		<-done
+	}
+	return err
+}
@@ -0,0 +1,22 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows || plan9
+// +build windows plan9
+
+package external
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on platforms without process groups.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// terminate interrupts the process started by cmd.
+func terminate(cmd *exec.Cmd) { cmd.Process.Signal(os.Interrupt) }
+
+// kill kills the process started by cmd.
+func kill(cmd *exec.Cmd) { cmd.Process.Kill() }
@@ -0,0 +1,105 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gopkg.in/check.v1"
+)
+
+type Sleep struct {
+	Cmd      string        `buildarg:"{{if .}}{{.}}{{else}}sleep{{end}}"` // sleep
+	Duration time.Duration `buildarg:"{{.Seconds}}"`                      // <seconds>
+}
+
+func (s Sleep) BuildCommand() (*exec.Cmd, error) {
+	cl := Must(Build(s))
+	return exec.Command(cl[0], cl[1:]...), nil
+}
+
+func (s Sleep) Timeout() time.Duration { return s.Duration / 2 }
+
+// exited returns whether the process pid has exited, allowing
+// for it remaining as a zombie if it has not been reaped.
+func exited(pid int) bool {
+	if syscall.Kill(pid, 0) != nil {
+		return true
+	}
+	stat, err := ioutil.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	return err == nil && strings.Contains(string(stat), ") Z ")
+}
+
+func (s *S) TestRunnerFor(c *check.C) {
+	c.Check(Runner{}.For(Sleep{Duration: time.Hour}).Timeout, check.Equals, 30*time.Minute)
+	c.Check(Runner{Timeout: time.Minute}.For(Sleep{Duration: time.Hour}).Timeout, check.Equals, time.Minute)
+	c.Check(Runner{Timeout: time.Minute}.For(Sleep{}).Timeout, check.Equals, time.Minute)
+	c.Check(Runner{Timeout: time.Minute}.For(Ls{}).Timeout, check.Equals, time.Minute)
+}
+
+func (s *S) TestRunnerComplete(c *check.C) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		c.Skip("sleep not present")
+	}
+	cmd, err := Sleep{Duration: 10 * time.Millisecond}.BuildCommand()
+	c.Assert(err, check.Equals, nil)
+	c.Check(Runner{Timeout: 10 * time.Second}.Run(context.Background(), cmd), check.Equals, nil)
+}
+
+func (s *S) TestRunnerTimeoutGroup(c *check.C) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		c.Skip("sh not present")
+	}
+	cmd := exec.Command("sh", "-c", "sleep 30 & echo $!; wait")
+	out := &bytes.Buffer{}
+	cmd.Stdout = out
+	start := time.Now()
+	err := Runner{Timeout: 200 * time.Millisecond, Grace: 5 * time.Second}.Run(context.Background(), cmd)
+	c.Check(err, check.Equals, ErrTimeout)
+	c.Check(time.Since(start) < 5*time.Second, check.Equals, true)
+
+	pid, err := strconv.Atoi(strings.TrimSpace(out.String()))
+	c.Assert(err, check.Equals, nil)
+	deadline := time.Now().Add(2 * time.Second)
+	for !exited(pid) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Check(exited(pid), check.Equals, true, check.Commentf("grandchild %d still running", pid))
+}
+
+func (s *S) TestRunnerGrace(c *check.C) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		c.Skip("sh not present")
+	}
+	const grace = 300 * time.Millisecond
+	cmd := exec.Command("sh", "-c", `trap "" TERM; sleep 30`)
+	start := time.Now()
+	err := Runner{Timeout: 100 * time.Millisecond, Grace: grace}.Run(context.Background(), cmd)
+	elapsed := time.Since(start)
+	c.Check(err, check.Equals, ErrTimeout)
+	c.Check(elapsed >= grace, check.Equals, true, check.Commentf("killed after %v", elapsed))
+	c.Check(elapsed < 10*time.Second, check.Equals, true, check.Commentf("killed after %v", elapsed))
+}
+
+func (s *S) TestRunnerCancel(c *check.C) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		c.Skip("sleep not present")
+	}
+	cmd, err := Sleep{Duration: 30 * time.Second}.BuildCommand()
+	c.Assert(err, check.Equals, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	c.Check(Runner{}.Run(ctx, cmd), check.Equals, context.Canceled)
+}
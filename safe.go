@@ -0,0 +1,92 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// pathTag returns the direction, "in" or "out", given by the value of a path tag and
+// whether the tag has the strict option.
+func pathTag(tag, name string) (dir string, strict bool, err error) {
+	parts := strings.Split(tag, ",")
+	dir = parts[0]
+	if dir != "in" && dir != "out" {
+		return "", false, fmt.Errorf("external: invalid path tag %q on field %s", tag, name)
+	}
+	for _, o := range parts[1:] {
+		if o != "strict" {
+			return "", false, fmt.Errorf("external: unknown path option %q on field %s", o, name)
+		}
+		strict = true
+	}
+	return dir, strict, nil
+}
+
+// sanitize returns value, the value of the field f to be passed to its template, after
+// checking that it holds no NUL and, for fields with a path tag, rewriting values that
+// begin with '-' to begin with "./" so they are not read as flags. If the path tag has
+// the strict option, a path value that begins with '-' is an error. The standard stream
+// name "-" is not rewritten.
+func sanitize(f field, value interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(value)
+	var isPath, strict bool
+	if tag, ok := f.tag.Lookup("path"); ok {
+		_, s, err := pathTag(tag, f.name)
+		if err != nil {
+			return nil, err
+		}
+		isPath, strict = true, s
+	}
+	check := func(s string) (string, error) {
+		if strings.ContainsRune(s, 0) {
+			return "", fmt.Errorf("external: field %s value %q contains NUL", f.name, s)
+		}
+		if !isPath || len(s) < 2 || s[0] != '-' {
+			return s, nil
+		}
+		if strict {
+			return "", fmt.Errorf("external: field %s path %q begins with '-'", f.name, s)
+		}
+		return "./" + s, nil
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		s, err := check(rv.String())
+		if err != nil {
+			return nil, err
+		}
+		if s == rv.String() {
+			return value, nil
+		}
+		return s, nil
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.String {
+			break
+		}
+		var rewritten []string
+		for i := 0; i < rv.Len(); i++ {
+			s, err := check(rv.Index(i).String())
+			if err != nil {
+				return nil, err
+			}
+			if s != rv.Index(i).String() && rewritten == nil {
+				rewritten = make([]string, rv.Len())
+				for j := 0; j < i; j++ {
+					rewritten[j] = rv.Index(j).String()
+				}
+			}
+			if rewritten != nil {
+				rewritten[i] = s
+			}
+		}
+		if rewritten != nil {
+			return rewritten, nil
+		}
+	}
+	return value, nil
+}
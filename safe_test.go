@@ -0,0 +1,86 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"os/exec"
+
+	"gopkg.in/check.v1"
+)
+
+type Safe struct {
+	Name    string    `opt:"-n"`
+	Out     string    `opt:"-o" path:"out"`
+	Refs    [2]string `pos:"" path:"in"`
+	InFiles []string  `pos:"" path:"in"`
+}
+
+func (s Safe) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+type StrictSafe struct {
+	Out     string   `opt:"-o" path:"out,strict"`
+	InFiles []string `pos:"" path:"in,strict"`
+}
+
+func (s StrictSafe) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+type BadPath struct {
+	In string `pos:"" path:"in,safe"`
+}
+
+func (b BadPath) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+func (s *S) TestSanitize(c *check.C) {
+	for _, t := range []struct {
+		cb   CommandBuilder
+		want []string
+		err  string
+	}{
+		{
+			cb:   Safe{Name: "-x", Out: "out", Refs: [2]string{"a", "b"}, InFiles: []string{"in", "-"}},
+			want: []string{"-n", "-x", "-o", "out", "a", "b", "in", "-"},
+		},
+		{
+			cb:   Safe{Out: "-out", Refs: [2]string{"a", "-b"}, InFiles: []string{"in", "-o/etc/x"}},
+			want: []string{"-o", "./-out", "a", "./-b", "in", "./-o/etc/x"},
+		},
+		{
+			cb:  Safe{InFiles: []string{"in\x00-o\x00/etc/x"}},
+			err: `external: field InFiles value "in\\x00-o\\x00/etc/x" contains NUL`,
+		},
+		{
+			cb:  Safe{Name: "a\x00b"},
+			err: `external: field Name value "a\\x00b" contains NUL`,
+		},
+		{
+			cb:   StrictSafe{Out: "out", InFiles: []string{"in", "-"}},
+			want: []string{"-o", "out", "in", "-"},
+		},
+		{
+			cb:  StrictSafe{Out: "-out"},
+			err: `external: field Out path "-out" begins with '-'`,
+		},
+		{
+			cb:  StrictSafe{InFiles: []string{"in", "-o/etc/x"}},
+			err: `external: field InFiles path "-o/etc/x" begins with '-'`,
+		},
+		{
+			cb:  BadPath{In: "in"},
+			err: `external: unknown path option "safe" on field In`,
+		},
+	} {
+		args, err := Build(t.cb)
+		if t.err != "" {
+			c.Check(err, check.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, t.want)
+	}
+
+	p, err := DeclaredPaths(StrictSafe{Out: "out", InFiles: []string{"in"}})
+	c.Check(err, check.Equals, nil)
+	c.Check(p, check.DeepEquals, Paths{In: []string{"in"}, Out: []string{"out"}})
+}
@@ -0,0 +1,325 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// field is the argument specification of a field of a CommandBuilder.
+type field struct {
+	index int
+	name  string
+	tag   reflect.StructTag
+
+	// text is the template text
+	// for the field's arguments.
+	text string
+
+	// order is the explicit argument
+	// order of the field, or zero.
+	order int
+
+	// pos indicates the field holds
+	// positional arguments.
+	pos bool
+
+	// extra indicates the field holds
+	// unmodelled arguments.
+	extra bool
+
+	// cmd indicates the field holds
+	// the command name.
+	cmd bool
+}
+
+// rank returns the class and order used to place the arguments of f. The command
+// field comes first, followed by fields with a negative order, then unordered
+// non-positional fields in declaration order, unordered extra argument fields,
+// fields with a positive order, and finally unordered positional fields in
+// declaration order.
+func (f field) rank() (class, order int) {
+	switch {
+	case f.cmd:
+		return -1, 0
+	case f.order < 0:
+		return 0, f.order
+	case f.order > 0:
+		return 3, f.order
+	case f.extra:
+		return 2, 0
+	case f.pos:
+		return 4, 0
+	}
+	return 1, 0
+}
+
+// fields returns the argument specifications of the fields of the struct type t in
+// argument order. Fields without an argument specification are not included.
+func fields(t reflect.Type) ([]field, error) {
+	var fs []field
+	slots := make(map[int]string)
+	for i := 0; i < t.NumField(); i++ {
+		tf := t.Field(i)
+		if tf.PkgPath != "" && !tf.Anonymous {
+			continue
+		}
+		text, err := argText(tf)
+		if err != nil {
+			return nil, err
+		}
+		if text == "" {
+			continue
+		}
+		f := field{index: i, name: tf.Name, tag: tf.Tag, text: text}
+		_, f.pos = tf.Tag.Lookup("pos")
+		_, f.extra = tf.Tag.Lookup("extra")
+		if o, ok := tf.Tag.Lookup("order"); ok {
+			f.order, err = strconv.Atoi(o)
+			if err != nil || f.order == 0 {
+				return nil, fmt.Errorf("external: invalid order %q on field %s", o, tf.Name)
+			}
+			if other, ok := slots[f.order]; ok {
+				return nil, fmt.Errorf("external: fields %s and %s both have order %d", other, tf.Name, f.order)
+			}
+			slots[f.order] = tf.Name
+		}
+		// The first field is the command unless
+		// it is explicitly ordered or holds
+		// positional or extra arguments.
+		f.cmd = len(fs) == 0 && !f.pos && !f.extra && f.order == 0
+		fs = append(fs, f)
+	}
+	sort.SliceStable(fs, func(i, j int) bool {
+		ci, oi := fs[i].rank()
+		cj, oj := fs[j].rank()
+		if ci != cj {
+			return ci < cj
+		}
+		return oi < oj
+	})
+	return fs, nil
+}
+
+// argText returns the template text for the argument specification of tf. This is
+// either the field's buildarg template or the template equivalent to its compact flag,
+// opt, pos or extra tag as described in the documentation for Build. It is an error
+// for a field to have more than one specification.
+func argText(tf reflect.StructField) (string, error) {
+	var (
+		text string
+		n    int
+	)
+	if tag := tf.Tag.Get("buildarg"); tag != "" {
+		text = tag
+		n++
+	}
+	if flag, ok := tf.Tag.Lookup("flag"); ok {
+		if err := checkFlag(tf, flag); err != nil {
+			return "", err
+		}
+		text = "{{if .}}" + flag + "{{end}}"
+		n++
+	}
+	if opt, ok := tf.Tag.Lookup("opt"); ok {
+		parts := strings.Split(opt, ",")
+		flag := parts[0]
+		if err := checkFlag(tf, flag); err != nil {
+			return "", err
+		}
+		var repeat, eq bool
+		for _, o := range parts[1:] {
+			switch o {
+			case "repeat":
+				repeat = true
+			case "eq":
+				eq = true
+			default:
+				return "", fmt.Errorf("external: unknown opt option %q on field %s", o, tf.Name)
+			}
+		}
+		sep := "{{split}}"
+		if eq {
+			sep = "="
+		}
+		switch {
+		case repeat:
+			if !isList(tf.Type) {
+				return "", fmt.Errorf("external: repeat option on non-slice field %s", tf.Name)
+			}
+			text = "{{range .}}" + flag + sep + "{{.}}{{split}}{{end}}"
+		case isList(tf.Type) && eq:
+			text = "{{if .}}" + flag + sep + "{{join \",\" .}}{{end}}"
+		case isList(tf.Type):
+			text = "{{if .}}" + flag + sep + "{{args .}}{{end}}"
+		default:
+			text = "{{if .}}" + flag + sep + "{{.}}{{end}}"
+		}
+		n++
+	}
+	if _, ok := tf.Tag.Lookup("pos"); ok {
+		if isList(tf.Type) {
+			text = "{{args .}}"
+		} else {
+			text = "{{.}}"
+		}
+		n++
+	}
+	if _, ok := tf.Tag.Lookup("extra"); ok {
+		if tf.Type.Kind() != reflect.Slice || tf.Type.Elem().Kind() != reflect.String {
+			return "", fmt.Errorf("external: extra argument field %s is not a string slice", tf.Name)
+		}
+		text = "{{args .}}"
+		n++
+	}
+	if n > 1 {
+		return "", fmt.Errorf("external: multiple argument specifications on field %s", tf.Name)
+	}
+	return text, nil
+}
+
+// checkFlag returns an error if flag is not a valid compact tag flag.
+func checkFlag(tf reflect.StructField, flag string) error {
+	if flag == "" || strings.ContainsAny(flag, "\x00{}") {
+		return fmt.Errorf("external: invalid flag %q on field %s", flag, tf.Name)
+	}
+	return nil
+}
+
+// isList returns whether t is an array or slice type.
+func isList(t reflect.Type) bool {
+	k := t.Kind()
+	return k == reflect.Array || k == reflect.Slice
+}
+
+// checkExtra returns an error if any argument held by an extra argument field of v
+// names a flag that is modelled by another field in fs.
+func checkExtra(fs []field, v reflect.Value) error {
+	var modelled map[string]string
+	for _, f := range fs {
+		if !f.extra {
+			continue
+		}
+		extra := v.Field(f.index)
+		if extra.Len() == 0 {
+			continue
+		}
+		if modelled == nil {
+			var err error
+			modelled, err = modelledFlags(fs)
+			if err != nil {
+				return err
+			}
+		}
+		for i := 0; i < extra.Len(); i++ {
+			arg := extra.Index(i).String()
+			if !isFlag(arg) {
+				continue
+			}
+			name := arg
+			if i := strings.Index(name, "="); i >= 0 {
+				name = name[:i]
+			}
+			if other, ok := modelled[name]; ok {
+				return fmt.Errorf("external: extra argument %q clashes with field %s", arg, other)
+			}
+			// Single letter flags may be given with an attached value.
+			if !strings.HasPrefix(arg, "--") && len(arg) > 2 {
+				if other, ok := modelled[arg[:2]]; ok {
+					return fmt.Errorf("external: extra argument %q clashes with field %s", arg, other)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// modelledFlags returns a map from the flags that appear in the templates of the
+// non-extra fields in fs to the names of the fields.
+func modelledFlags(fs []field) (map[string]string, error) {
+	flags := make(map[string]string)
+	for _, f := range fs {
+		if f.extra {
+			continue
+		}
+		t := parse.New(f.name)
+		t.Mode = parse.SkipFuncCheck
+		_, err := t.Parse(f.text, "", "", make(map[string]*parse.Tree))
+		if err != nil {
+			return nil, err
+		}
+		walkText(t.Root, func(text string) {
+			for _, tok := range strings.FieldsFunc(text, func(r rune) bool {
+				return r == 0 || r == ' ' || r == '\t'
+			}) {
+				if i := strings.IndexAny(tok, "=%"); i >= 0 {
+					tok = tok[:i]
+				}
+				if isFlag(tok) {
+					if _, ok := flags[tok]; !ok {
+						flags[tok] = f.name
+					}
+				}
+			}
+		})
+	}
+	return flags, nil
+}
+
+// walkText calls fn with the text of each text and string constant node in the
+// parse tree rooted at n.
+func walkText(n parse.Node, fn func(string)) {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			walkText(c, fn)
+		}
+	case *parse.TextNode:
+		fn(string(n.Text))
+	case *parse.StringNode:
+		fn(n.Text)
+	case *parse.ActionNode:
+		walkText(n.Pipe, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			walkText(c, fn)
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			walkText(a, fn)
+		}
+	case *parse.IfNode:
+		walkText(&n.BranchNode, fn)
+	case *parse.RangeNode:
+		walkText(&n.BranchNode, fn)
+	case *parse.WithNode:
+		walkText(&n.BranchNode, fn)
+	case *parse.BranchNode:
+		walkText(n.Pipe, fn)
+		walkText(n.List, fn)
+		walkText(n.ElseList, fn)
+	}
+}
+
+// isFlag returns whether arg has the form of a command line flag rather than a
+// value or a negative number.
+func isFlag(arg string) bool {
+	if len(arg) < 2 || arg[0] != '-' || arg == "--" {
+		return false
+	}
+	_, err := strconv.ParseFloat(arg, 64)
+	return err != nil
+}
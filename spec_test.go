@@ -0,0 +1,258 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package external
+
+import (
+	"os/exec"
+
+	"gopkg.in/check.v1"
+)
+
+// Verbose and Compact specify the same command line.
+type Verbose struct {
+	Cmd     string   `buildarg:"{{if .}}{{.}}{{else}}tool{{end}}"`
+	Protein bool     `buildarg:"{{if .}}-p{{end}}"`
+	Seed    string   `buildarg:"{{if .}}-m{{split}}{{.}}{{end}}"`
+	Weight  float64  `buildarg:"{{if .}}-w{{split}}{{.}}{{end}}"`
+	Seeds   []string `buildarg:"{{if .}}{{mprintf \"--seed\x00%v\" . | args}}{{end}}"`
+	Thread  int      `buildarg:"{{if .}}--thread={{.}}{{end}}"`
+	Pair    []int    `buildarg:"{{if .}}-x{{split}}{{args .}}{{end}}"`
+	Exclude []string `buildarg:"{{if .}}{{mprintf \"--exclude=%v\" . | args}}{{end}}"`
+	List    []string `buildarg:"{{if .}}--list={{join \",\" .}}{{end}}"`
+	DB      string   `buildarg:"{{.}}"`
+	InFiles []string `buildarg:"{{args .}}"`
+}
+
+func (v Verbose) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+type Compact struct {
+	Cmd     string   `buildarg:"{{if .}}{{.}}{{else}}tool{{end}}"`
+	Protein bool     `flag:"-p"`
+	Seed    string   `opt:"-m"`
+	Weight  float64  `opt:"-w"`
+	Seeds   []string `opt:"--seed,repeat"`
+	Thread  int      `opt:"--thread,eq"`
+	Pair    []int    `opt:"-x"`
+	Exclude []string `opt:"--exclude,repeat,eq"`
+	List    []string `opt:"--list,eq"`
+	DB      string   `pos:""`
+	InFiles []string `pos:""`
+}
+
+func (c Compact) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+func (s *S) TestCompactTags(c *check.C) {
+	for _, t := range []struct {
+		v    Verbose
+		want []string
+	}{
+		{
+			v:    Verbose{},
+			want: []string{"tool"},
+		},
+		{
+			v: Verbose{
+				Cmd:     "/bin/tool",
+				Protein: true,
+				Seed:    "1110",
+				Weight:  0.5,
+				Seeds:   []string{"a", "b"},
+				Thread:  4,
+				Pair:    []int{1, 2},
+				Exclude: []string{"x", "y"},
+				List:    []string{"l", "m"},
+				DB:      "db",
+				InFiles: []string{"in1", "in2"},
+			},
+			want: []string{
+				"/bin/tool", "-p", "-m", "1110", "-w", "0.5", "--seed", "a", "--seed", "b",
+				"--thread=4", "-x", "1", "2", "--exclude=x", "--exclude=y", "--list=l,m",
+				"db", "in1", "in2",
+			},
+		},
+	} {
+		args, err := Build(t.v)
+		c.Check(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, t.want)
+		args, err = Build(Compact(t.v))
+		c.Check(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, t.want)
+	}
+}
+
+type badTags struct {
+	Ls
+	Multiple bool `flag:"-a" opt:"-b"`
+}
+
+type badRepeat struct {
+	Ls
+	Repeat string `opt:"-r,repeat"`
+}
+
+type badOption struct {
+	Ls
+	Option string `opt:"-o,often"`
+}
+
+type badFlag struct {
+	Ls
+	Flag bool `flag:""`
+}
+
+func (s *S) TestCompactTagErrors(c *check.C) {
+	for _, t := range []struct {
+		cb  CommandBuilder
+		err string
+	}{
+		{badTags{}, "external: multiple argument specifications on field Multiple"},
+		{badRepeat{}, "external: repeat option on non-slice field Repeat"},
+		{badOption{}, `external: unknown opt option "often" on field Option`},
+		{badFlag{}, `external: invalid flag "" on field Flag`},
+	} {
+		_, err := Build(t.cb)
+		c.Check(err, check.ErrorMatches, t.err)
+	}
+}
+
+type Ordered struct {
+	Query   []string `pos:"" order:"2"`
+	Ref     string   `pos:"" order:"1"`
+	Files   []string `pos:""`
+	Cmd     string   `buildarg:"{{if .}}{{.}}{{else}}tool{{end}}" order:"-2"`
+	Verbose bool     `flag:"-v"`
+	SubCmd  struct{} `buildarg:"sub" order:"-1"`
+	Strand  int      `opt:"-s"`
+}
+
+func (o Ordered) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+type cmdFirst struct {
+	Cmd     string `buildarg:"{{if .}}{{.}}{{else}}tool{{end}}"`
+	Verbose bool   `flag:"-v"`
+	SubCmd  string `pos:"" order:"-1"`
+	Early   bool   `flag:"-e" order:"-2"`
+	In      string `pos:""`
+}
+
+func (c cmdFirst) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+type sameOrder struct {
+	Ls
+	A string `pos:"" order:"1"`
+	B string `pos:"" order:"1"`
+}
+
+type zeroOrder struct {
+	Ls
+	A string `pos:"" order:"0"`
+}
+
+func (s *S) TestOrder(c *check.C) {
+	for _, t := range []struct {
+		cb   CommandBuilder
+		want []string
+	}{
+		{
+			cb: Ordered{
+				Query:   []string{"q1", "q2"},
+				Ref:     "ref",
+				Files:   []string{"f"},
+				Verbose: true,
+				Strand:  2,
+			},
+			want: []string{"tool", "sub", "-v", "-s", "2", "ref", "q1", "q2", "f"},
+		},
+		{
+			cb:   cmdFirst{Verbose: true, SubCmd: "index", Early: true, In: "in"},
+			want: []string{"tool", "-e", "index", "-v", "in"},
+		},
+	} {
+		args, err := Build(t.cb)
+		c.Check(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, t.want)
+	}
+
+	_, err := Build(sameOrder{})
+	c.Check(err, check.ErrorMatches, "external: fields A and B both have order 1")
+	_, err = Build(zeroOrder{})
+	c.Check(err, check.ErrorMatches, `external: invalid order "0" on field A`)
+}
+
+type Extras struct {
+	Cmd     string   `buildarg:"{{if .}}{{.}}{{else}}tool{{end}}"`
+	DB      string   `pos:"" order:"1"`
+	Verbose bool     `flag:"-v"`
+	Seeds   []string `buildarg:"{{if .}}{{mprintf \"--seed\x00%v\" . | args}}{{end}}"`
+	Thread  int      `opt:"--thread,eq"`
+	Extra   []string `extra:""`
+	Strand  int      `opt:"-s"`
+	InFiles []string `pos:""`
+}
+
+func (e Extras) BuildCommand() (*exec.Cmd, error) { return nil, nil }
+
+type (
+	extraArgs []string
+	extraArg  string
+)
+
+type namedExtras struct {
+	Ls
+	Verbose bool       `flag:"-v"`
+	Args    extraArgs  `extra:""`
+	More    []extraArg `extra:""`
+}
+
+type badExtra struct {
+	Ls
+	Extra []int `extra:""`
+}
+
+func (s *S) TestExtra(c *check.C) {
+	for _, t := range []struct {
+		extra []string
+		want  []string
+		err   string
+	}{
+		{
+			extra: nil,
+			want:  []string{"tool", "-v", "-s", "2", "db", "in"},
+		},
+		{
+			extra: []string{"-P", "4", "--new=x", "-x", "-1", "value"},
+			want:  []string{"tool", "-v", "-s", "2", "-P", "4", "--new=x", "-x", "-1", "value", "db", "in"},
+		},
+		{extra: []string{"-v"}, err: `external: extra argument "-v" clashes with field Verbose`},
+		{extra: []string{"-s3"}, err: `external: extra argument "-s3" clashes with field Strand`},
+		{extra: []string{"--seed", "1"}, err: `external: extra argument "--seed" clashes with field Seeds`},
+		{extra: []string{"--thread=2"}, err: `external: extra argument "--thread=2" clashes with field Thread`},
+	} {
+		args, err := Build(Extras{
+			DB:      "db",
+			Verbose: true,
+			Extra:   t.extra,
+			Strand:  2,
+			InFiles: []string{"in"},
+		})
+		if t.err != "" {
+			c.Check(err, check.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, check.Equals, nil)
+		c.Check(args, check.DeepEquals, t.want)
+	}
+
+	args, err := Build(namedExtras{Args: extraArgs{"-P", "4"}, More: []extraArg{"-x"}})
+	c.Check(err, check.Equals, nil)
+	c.Check(args, check.DeepEquals, []string{"-P", "4", "-x"})
+	_, err = Build(namedExtras{Args: extraArgs{"-v"}})
+	c.Check(err, check.ErrorMatches, `external: extra argument "-v" clashes with field Verbose`)
+	_, err = Build(namedExtras{More: []extraArg{"-v"}})
+	c.Check(err, check.ErrorMatches, `external: extra argument "-v" clashes with field Verbose`)
+
+	_, err = Build(badExtra{})
+	c.Check(err, check.ErrorMatches, "external: extra argument field Extra is not a string slice")
+}